@@ -0,0 +1,46 @@
+package gocron
+
+import (
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/jasonlvhit/gocron/internal/apidump"
+)
+
+var updateAPI = flag.Bool("update-api", false, "rewrite testdata/api.txt with the current exported API")
+
+const apiGolden = "testdata/api.txt"
+
+// TestAPI fails when an exported identifier recorded in testdata/api.txt
+// is removed or changes signature. Deliberate changes are recorded by
+// running go test -run TestAPI -update-api and committing the result.
+func TestAPI(t *testing.T) {
+	current, err := apidump.Dir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *updateAPI {
+		if err := ioutil.WriteFile(apiGolden, []byte(strings.Join(current, "\n")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	b, err := ioutil.ReadFile(apiGolden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := strings.Split(strings.TrimSpace(string(b)), "\n")
+	removed, added := apidump.Diff(golden, current)
+	for _, line := range removed {
+		t.Errorf("removed or changed: %s", line)
+	}
+	for _, line := range added {
+		t.Logf("added: %s", line)
+	}
+	if len(removed) > 0 {
+		t.Log("if the change is intended, run go test -run TestAPI -update-api")
+	}
+}
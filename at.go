@@ -0,0 +1,200 @@
+package gocron
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeOfDay is a time of day set with At
+type timeOfDay struct {
+	hour, min, sec int
+}
+
+func (c timeOfDay) String() string {
+	if c.sec != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", c.hour, c.min, c.sec)
+	}
+	return fmt.Sprintf("%02d:%02d", c.hour, c.min)
+}
+
+// Map a time with a leap second, second 60, to the start of the next
+// minute, which is midnight for 23:59:60. Jobs cannot run on the leap
+// second itself, the time package does not represent them.
+func normalizeClock(hour, min, sec int) (c timeOfDay, leap bool) {
+	if sec != 60 {
+		return timeOfDay{hour, min, sec}, false
+	}
+	next := (hour*60 + min + 1) % (24 * 60)
+	return timeOfDay{next / 60, next % 60, 0}, true
+}
+
+// Note that At time t was normalized to at
+func (j *Job) logLeap(t, at string) {
+	if j.scheduler != nil {
+		j.scheduler.logf("At time %q has a leap second, the job runs at %s instead", t, at)
+	}
+}
+
+// On the date of t
+func (c timeOfDay) on(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), c.hour, c.min, c.sec, 0, t.Location())
+}
+
+// Since midnight
+func (c timeOfDay) offset() time.Duration {
+	return time.Duration(c.hour)*time.Hour + time.Duration(c.min)*time.Minute + time.Duration(c.sec)*time.Second
+}
+
+// AtTime - The job's At time as the offset from midnight, the earliest one
+// for a job with several, e.g. 10h30m15s for At("10:30:15"). For hourly
+// and minutely jobs it is the offset within the hour or minute. It is zero
+// for a job without an At time.
+func (j *Job) AtTime() time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.hasOffset() {
+		return j.offset
+	}
+	if len(j.ats) == 0 {
+		return 0
+	}
+	return j.ats[0].offset()
+}
+
+// AtTimes - All of the job's At times as offsets from midnight, in order
+func (j *Job) AtTimes() []time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var times []time.Duration
+	for _, c := range j.ats {
+		times = append(times, c.offset())
+	}
+	return times
+}
+
+// Merge times into the job's At times, keeping them sorted without
+// duplicates
+func (j *Job) addAts(times []timeOfDay) {
+	ats := append(j.ats, times...)
+	sort.Slice(ats, func(a, b int) bool { return ats[a].offset() < ats[b].offset() })
+	j.ats = ats[:0]
+	for _, c := range ats {
+		if len(j.ats) == 0 || j.ats[len(j.ats)-1] != c {
+			j.ats = append(j.ats, c)
+		}
+	}
+	names := make([]string, len(j.ats))
+	for i, c := range j.ats {
+		names[i] = c.String()
+	}
+	j.atTime = strings.Join(names, ";")
+}
+
+// The latest At time on the day of now that is before now
+func (j *Job) latestAtBefore(now time.Time) (timeOfDay, bool) {
+	for i := len(j.ats) - 1; i >= 0; i-- {
+		if now.After(j.ats[i].on(now)) {
+			return j.ats[i], true
+		}
+	}
+	return timeOfDay{}, false
+}
+
+// The first At time after t on the same day
+func (j *Job) laterAt(t time.Time) (time.Time, bool) {
+	for _, c := range j.ats {
+		if at := c.on(t); at.After(t) {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// The run after last of a daily or weekly job with several At times: the
+// next one on the same day, or else the first one days later
+func (j *Job) nextAt(last time.Time, days int) time.Time {
+	if j.unit != UnitWeeks || last.Weekday() == j.startDay {
+		if at, ok := j.laterAt(last); ok {
+			return at
+		}
+	}
+	return j.ats[0].on(last.AddDate(0, 0, days))
+}
+
+// Set the At offset of an hourly job, "MM" or "MM:SS" past the hour, or of
+// a minutely job, "SS" past the minute
+func (j *Job) atOffset(t string) {
+	fields := strings.Split(t, ":")
+	if j.unit == UnitMinutes && len(fields) != 1 || len(fields) > 2 {
+		j.setErr(errors.New("invalid At time " + strconv.Quote(t) + ": want " + offsetFormat(j.unit)))
+		return
+	}
+	if j.atTime != "" {
+		j.setErr(errors.New("At() takes a single time for hourly and minutely jobs"))
+		return
+	}
+	var offset time.Duration
+	units := []time.Duration{time.Minute, time.Second}
+	if j.unit == UnitMinutes {
+		units = units[1:]
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		leap := n == 60 && units[i] == time.Second
+		if err != nil || n < 0 || n > 59 && !leap || len(field) > 2 || i > 0 && len(field) != 2 {
+			j.setErr(errors.New("invalid At time " + strconv.Quote(t) + ": want " + offsetFormat(j.unit)))
+			return
+		}
+		offset += time.Duration(n) * units[i]
+	}
+	if length := unitLength(j.unit); offset >= length {
+		// a leap second past the last minute or second of the period
+		offset -= length
+		j.logLeap(t, "the start of the "+strings.TrimSuffix(j.unit, "s"))
+	}
+	j.offset = offset
+	j.atTime = t
+}
+
+func offsetFormat(unit string) string {
+	if unit == UnitMinutes {
+		return "seconds past the minute, SS"
+	}
+	return "minutes past the hour, MM or MM:SS"
+}
+
+// Whether the job is hourly or minutely with an At offset, requires j.mu held
+func (j *Job) hasOffset() bool {
+	return (j.unit == UnitHours || j.unit == UnitMinutes) && j.atTime != "" && len(j.ats) == 0
+}
+
+// How long one unit is, for units of a fixed length
+func unitLength(unit string) time.Duration {
+	switch unit {
+	case UnitSeconds:
+		return time.Second
+	case UnitMinutes:
+		return time.Minute
+	case UnitHours:
+		return time.Hour
+	}
+	return 0
+}
+
+// The first time after from at the job's offset within the hour or minute
+func (j *Job) nextOffset(from time.Time) time.Time {
+	t := from.In(j.location())
+	start := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	if j.unit == UnitHours {
+		start = start.Add(-time.Duration(t.Minute()) * time.Minute)
+	}
+	next := start.Add(j.offset)
+	if !next.After(from) {
+		next = next.Add(unitLength(j.unit))
+	}
+	return next
+}
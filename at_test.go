@@ -0,0 +1,230 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJob_AtSeveralTimes(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Day().At("17:30").At("13:00;09:00").At("09:00").Loc(time.UTC)
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := job.atTime, "09:00;13:00;17:30"; got != want {
+		t.Errorf("At times %q, want %q", got, want)
+	}
+
+	day := func(d, hour, min, sec int) time.Time {
+		return time.Date(2023, time.March, d, hour, min, sec, 0, time.UTC)
+	}
+	cases := []struct{ last, want time.Time }{
+		{day(10, 9, 0, 0), day(10, 13, 0, 0)},
+		{day(10, 13, 0, 1), day(10, 17, 30, 0)},
+		{day(10, 17, 30, 0), day(11, 9, 0, 0)},
+		{day(31, 17, 30, 0), time.Date(2023, time.April, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		job.mu.Lock()
+		job.lastRun = c.last
+		job.scheduleNextRun()
+		got := job.nextRun
+		job.mu.Unlock()
+		if !got.Equal(c.want) {
+			t.Errorf("after a run at %v: next run %v, want %v", c.last, got, c.want)
+		}
+	}
+}
+
+func TestJob_AtSeveralTimesFirstRun(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Day().At("00:00;08:00;16:00")
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().In(time.Local)
+	var want time.Time
+	for d := 0; d < 2 && want.IsZero(); d++ {
+		for _, hour := range []int{0, 8, 16} {
+			at := time.Date(now.Year(), now.Month(), now.Day()+d, hour, 0, 0, 0, time.Local)
+			if at.After(now) {
+				want = at
+				break
+			}
+		}
+	}
+	if next := job.NextScheduledTime(); !next.Equal(want) {
+		t.Errorf("first run %v, want %v", next, want)
+	}
+}
+
+func TestJob_AtSeveralTimesWeekly(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Monday().At("09:00;18:30").Loc(time.UTC)
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	monday := func(d, hour, min int) time.Time {
+		return time.Date(2023, time.March, d, hour, min, 0, 0, time.UTC)
+	}
+	cases := []struct{ last, want time.Time }{
+		{monday(6, 9, 0), monday(6, 18, 30)},
+		{monday(6, 18, 30), monday(13, 9, 0)},
+	}
+	for _, c := range cases {
+		job.mu.Lock()
+		job.lastRun = c.last
+		job.scheduleNextRun()
+		got := job.nextRun
+		job.mu.Unlock()
+		if !got.Equal(c.want) {
+			t.Errorf("after a run at %v: next run %v, want %v", c.last, got, c.want)
+		}
+	}
+	if next := job.NextScheduledTime(); next.Weekday() != time.Monday {
+		t.Errorf("next run %v is not on a Monday", next)
+	}
+}
+
+func TestJob_AtSeveralTimesInvalid(t *testing.T) {
+	scheduler := NewScheduler()
+	if err := scheduler.Every(1).Day().At("09:00;25:00").Do(func() {}); err == nil {
+		t.Error("a malformed time in the list should fail")
+	}
+}
+
+func TestJob_AtSeconds(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Day().At("10:30:15")
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := job.AtTime(), 10*time.Hour+30*time.Minute+15*time.Second; got != want {
+		t.Errorf("AtTime() = %v, want %v", got, want)
+	}
+	next := job.NextScheduledTime().In(time.Local)
+	if next.Hour() != 10 || next.Minute() != 30 || next.Second() != 15 {
+		t.Errorf("next run %v, want 10:30:15", next)
+	}
+	if until := time.Until(next); until <= 0 || until > 24*time.Hour {
+		t.Errorf("next run %v, want within a day", next)
+	}
+
+	several := scheduler.Every(1).Day().At("13:00;09:00:30")
+	if got := several.AtTimes(); len(got) != 2 || got[0] != 9*time.Hour+30*time.Second || got[1] != 13*time.Hour {
+		t.Errorf("AtTimes() = %v, want 9h0m30s and 13h", got)
+	}
+	if got := scheduler.Every(1).Hour().AtTime(); got != 0 {
+		t.Errorf("AtTime() of a job without At = %v", got)
+	}
+	for _, bad := range []string{"10:30:15:00", "24:00", "7:5", "10:30:61"} {
+		if err := scheduler.Every(1).Day().At(bad).Do(func() {}); err == nil {
+			t.Errorf("At(%q) should fail", bad)
+		}
+	}
+}
+
+func TestJob_AtOffset(t *testing.T) {
+	scheduler := NewScheduler()
+	at := func(hour, min, sec, ms int) time.Time {
+		return time.Date(2023, time.March, 10, hour, min, sec, ms*int(time.Millisecond), time.UTC)
+	}
+
+	hourly := scheduler.Every(1).Hour().At("30").Loc(time.UTC)
+	if err := hourly.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hourly.nextOffset(at(10, 47, 0, 0)), at(11, 30, 0, 0); !got.Equal(want) {
+		t.Errorf("an hourly job created at 10:47 first runs at %v, want %v", got, want)
+	}
+	next := hourly.NextScheduledTime()
+	if next.Minute() != 30 || next.Second() != 0 || time.Until(next) > time.Hour {
+		t.Errorf("first run %v, want the next half hour", next)
+	}
+
+	cases := []struct {
+		job        *Job
+		last, want time.Time
+	}{
+		{hourly, at(11, 30, 0, 200), at(12, 30, 0, 0)},
+		{hourly, at(11, 45, 0, 0), at(12, 30, 0, 0)},
+		{scheduler.Every(2).Hours().At("30:15").Loc(time.UTC), at(11, 30, 15, 5), at(13, 30, 15, 0)},
+		{scheduler.Every(1).Minute().At("15").Loc(time.UTC), at(11, 30, 15, 5), at(11, 31, 15, 0)},
+		{scheduler.Every(5).Minutes().At("00").Loc(time.UTC), at(11, 59, 59, 999), at(12, 04, 0, 0)},
+	}
+	for _, c := range cases {
+		if c.job != hourly {
+			if err := c.job.Do(func() {}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c.job.mu.Lock()
+		c.job.lastRun = c.last
+		c.job.scheduleNextRun()
+		got := c.job.nextRun
+		c.job.mu.Unlock()
+		if !got.Equal(c.want) {
+			t.Errorf("%s after a run at %v: next run %v, want %v", c.job, c.last, got, c.want)
+		}
+	}
+
+	if got := scheduler.Every(1).Hour().At("30:15").AtTime(); got != 30*time.Minute+15*time.Second {
+		t.Errorf("AtTime() = %v, want 30m15s", got)
+	}
+	for _, bad := range []*Job{
+		scheduler.Every(1).Hour().At("60"),
+		scheduler.Every(1).Hour().At("30:5"),
+		scheduler.Every(1).Hour().At("10:30:00"),
+		scheduler.Every(1).Minute().At("10:30"),
+		scheduler.Every(1).Hour().At("10").At("20"),
+	} {
+		if err := bad.Do(func() {}); err == nil {
+			t.Errorf("%s should fail", bad)
+		}
+	}
+}
+
+func TestJob_AtLeapSecond(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+
+	cases := []struct {
+		at   string
+		want time.Duration
+	}{
+		{"23:59:60", 0},
+		{"12:34:60", 12*time.Hour + 35*time.Minute},
+		{"12:59:60", 13 * time.Hour},
+	}
+	for _, c := range cases {
+		job := scheduler.Every(1).Day().At(c.at)
+		if err := job.Do(func() {}); err != nil {
+			t.Fatalf("At(%q): %v", c.at, err)
+		}
+		if got := job.AtTime(); got != c.want {
+			t.Errorf("At(%q) runs at %v past midnight, want %v", c.at, got, c.want)
+		}
+		next := job.NextScheduledTime().In(time.Local)
+		if next.Second() != 0 || time.Duration(next.Hour())*time.Hour+time.Duration(next.Minute())*time.Minute != c.want {
+			t.Errorf("At(%q) next run %v", c.at, next)
+		}
+		if until := time.Until(next); until <= 0 || until > 24*time.Hour {
+			t.Errorf("At(%q) next run %v, want within a day", c.at, next)
+		}
+	}
+
+	hourly := scheduler.Every(1).Hour().At("59:60")
+	if err := hourly.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if got := hourly.AtTime(); got != 0 {
+		t.Errorf("At(\"59:60\") of an hourly job runs at %v past the hour, want 0", got)
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.lines) != len(cases)+1 {
+		t.Errorf("got %d log lines, want one per normalized time: %q", len(logger.lines), logger.lines)
+	}
+}
@@ -0,0 +1,128 @@
+package gocron
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"strconv"
+)
+
+// building reports whether the builder method name may still change the
+// job. Once Do has scheduled the job its schedule is fixed and later
+// builder calls record an error instead, use Reschedule to change it.
+//
+// In debug mode, see SetDebug, a job also remembers the goroutine that
+// created it, and builder calls from any other goroutine before Do are
+// flagged, since the chain is not safe for concurrent use.
+func (j *Job) building(name string) bool {
+	j.mu.Lock()
+	finalized := j.jobFunc != ""
+	j.mu.Unlock()
+	if finalized {
+		err := errors.New(name + "() called after Do, the job's schedule can no longer change")
+		j.warn(err)
+		j.setErr(err)
+		return false
+	}
+	if j.creator != 0 && !j.misused {
+		if g := goroutineID(); g != j.creator {
+			j.misused = true
+			err := errors.New(name + "() called from goroutine " + strconv.FormatUint(g, 10) +
+				", the job was created on goroutine " + strconv.FormatUint(j.creator, 10))
+			j.warn(err)
+			j.setErr(err)
+		}
+	}
+	return true
+}
+
+// Set the job's unit, flagging a second, different one such as
+// Minutes().Hours(), which would otherwise silently win
+func (j *Job) setUnit(name, unit string) {
+	if j.unit != "" && j.unit != unit {
+		j.setErr(errors.New(name + "() called on a job that already runs in " + j.unit))
+		return
+	}
+	j.unit = unit
+}
+
+// When - Apply the schedule fragment when cond holds and keep the chain
+// going, e.g. s.Every(5).When(cfg.Fast, (*Job).Minutes).When(!cfg.Fast, (*Job).Hours)
+func (j *Job) When(cond bool, apply func(*Job) *Job) *Job {
+	if !cond {
+		return j
+	}
+	return j.Apply(apply)
+}
+
+// WhenElse - Apply ifTrue when cond holds and ifFalse otherwise
+func (j *Job) WhenElse(cond bool, ifTrue, ifFalse func(*Job) *Job) *Job {
+	if cond {
+		return j.Apply(ifTrue)
+	}
+	return j.Apply(ifFalse)
+}
+
+// Apply - Apply schedule fragments in order, so they can be shared by
+// many jobs, e.g.
+//
+//	nightly := func(j *gocron.Job) *gocron.Job { return j.Day().At("02:00") }
+//	s.Every(1).Apply(nightly).Do(backup)
+//
+// Errors of the fragments' builder calls are reported by Do as usual. A
+// fragment must return the job it was given.
+func (j *Job) Apply(fragments ...func(*Job) *Job) *Job {
+	for _, f := range fragments {
+		if f == nil {
+			continue
+		}
+		if got := f(j); got != j {
+			j.setErr(errors.New("a schedule fragment returned a different job"))
+		}
+	}
+	return j
+}
+
+func (j *Job) warn(err error) {
+	if j.scheduler != nil {
+		j.scheduler.logf("job %s: %v", j.funcName(), err)
+	}
+}
+
+func (j *Job) isFinalized() bool {
+	return j.funcName() != ""
+}
+
+// UnfinalizedJobs - The jobs added with Every on which Do was never called
+// successfully. They are not run, and NextRun and RunAll ignore them.
+func (s *Scheduler) UnfinalizedJobs() []*Job {
+	var jobs []*Job
+	for _, job := range s.snapshot() {
+		if !job.isFinalized() {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// Warn about jobs that were never finalized, typically a missing Do
+func (s *Scheduler) warnUnfinalized() {
+	if jobs := s.UnfinalizedJobs(); len(jobs) > 0 {
+		s.logf("%d jobs were added without a successful Do and will not run", len(jobs))
+	}
+}
+
+var goroutinePrefix = []byte("goroutine ")
+
+// goroutineID parses the current goroutine's id out of its stack header,
+// only used in debug mode
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, goroutinePrefix)
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
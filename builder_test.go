@@ -0,0 +1,212 @@
+package gocron
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJob_BuilderAfterDo(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+
+	job := scheduler.Every(1).Hour()
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	next := job.NextScheduledTime()
+
+	job.Minutes().At("10:30").OrderingKey("acme")
+	if job.unit != UnitHours || job.atTime != "" || job.orderingKey != "" {
+		t.Errorf("builder calls after Do changed the job: unit %s, at %q, key %q", job.unit, job.atTime, job.orderingKey)
+	}
+	if !job.NextScheduledTime().Equal(next) {
+		t.Error("builder calls after Do changed the next run")
+	}
+	if err := job.Err(); err == nil || !strings.HasPrefix(err.Error(), "Minutes() called after Do") {
+		t.Errorf("Err() = %v, want the first builder call after Do", err)
+	}
+	if err := job.Do(func() {}); err == nil {
+		t.Error("a second Do should fail")
+	}
+	logger.mu.Lock()
+	n := len(logger.lines)
+	logger.mu.Unlock()
+	if n != 3 {
+		t.Errorf("got %d warnings, want one per builder call", n)
+	}
+
+	// Reschedule is the way to change a scheduled job
+	if err := job.Reschedule(5, UnitMinutes); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJob_BuilderAcrossGoroutines(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+	scheduler.SetDebug(true)
+
+	job := scheduler.Every(1)
+	done := make(chan struct{})
+	go func() {
+		job.Minutes()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("builder call did not return")
+	}
+	if err := job.Do(func() {}); err == nil || !strings.Contains(err.Error(), "Minutes() called from goroutine") {
+		t.Errorf("Do() = %v, want the builder call from another goroutine flagged", err)
+	}
+	logger.mu.Lock()
+	n := len(logger.lines)
+	logger.mu.Unlock()
+	if n != 1 {
+		t.Errorf("got %d warnings, want 1", n)
+	}
+
+	// the same chain on one goroutine is fine
+	if err := scheduler.Every(1).Minutes().Do(func() {}); err != nil {
+		t.Error(err)
+	}
+	// and jobs are not tracked without debug mode
+	scheduler.SetDebug(false)
+	if job := scheduler.Every(1); job.creator != 0 {
+		t.Error("job created without debug mode has a creator")
+	}
+}
+
+func TestGoroutineID(t *testing.T) {
+	id := goroutineID()
+	if id == 0 {
+		t.Fatal("goroutineID() = 0")
+	}
+	other := make(chan uint64)
+	go func() { other <- goroutineID() }()
+	if o := <-other; o == id || o == 0 {
+		t.Errorf("another goroutine got id %d, this one %d", o, id)
+	}
+}
+
+func TestScheduler_UnfinalizedJobs(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+	forgotten := scheduler.Every(5).Minutes() // no Do
+	job := scheduler.Every(1).Hour()
+	job.Do(func() {})
+
+	if jobs := scheduler.UnfinalizedJobs(); len(jobs) != 1 || jobs[0] != forgotten {
+		t.Errorf("UnfinalizedJobs() = %v, want the job without Do", jobs)
+	}
+	if next, _ := scheduler.NextRun(); next != job {
+		t.Error("NextRun should skip the job without Do")
+	}
+	scheduler.RunAll()
+	scheduler.RunPending()
+	scheduler.Wait(time.Second)
+	if forgotten.RunCount() != 0 {
+		t.Error("the job without Do was dispatched")
+	}
+	for _, pass := range scheduler.RecentDispatchPasses(DispatchLogSize) {
+		for _, j := range pass.Dispatched {
+			if j == forgotten {
+				t.Error("the job without Do was dispatched by RunPending")
+			}
+		}
+	}
+
+	scheduler.StartWithContext(context.Background())
+	scheduler.Stop()
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "1 jobs were added without a successful Do") {
+		t.Errorf("got warnings %q, want one about the job without Do", logger.lines)
+	}
+}
+
+func TestJob_When(t *testing.T) {
+	scheduler := NewScheduler()
+	nightly := func(j *Job) *Job { return j.Day().At("02:00") }
+	tagged := func(j *Job) *Job { return j.Tag("batch") }
+
+	for _, fast := range []bool{true, false} {
+		straight := scheduler.Every(5).Hours()
+		if fast {
+			straight = scheduler.Every(5).Minutes()
+		}
+		chained := scheduler.Every(5).WhenElse(fast, (*Job).Minutes, (*Job).Hours)
+		if err := straight.Do(task); err != nil {
+			t.Fatal(err)
+		}
+		if err := chained.Do(task); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := schedule(chained), schedule(straight); got != want {
+			t.Errorf("fast=%v: conditional chain built %q, want %q", fast, got, want)
+		}
+	}
+
+	straight := scheduler.Every(1).Day().At("02:00").Tag("batch")
+	composed := scheduler.Every(1).Apply(nightly, tagged).When(false, (*Job).Hours)
+	straight.Do(task)
+	composed.Do(task)
+	if straight.String() != composed.String() || !straight.NextScheduledTime().Equal(composed.NextScheduledTime()) {
+		t.Errorf("Apply built %q, want %q", composed, straight)
+	}
+	if tags := composed.Tags(); len(tags) != 1 || tags[0] != "batch" {
+		t.Errorf("Tags() = %v, want the fragment's tag", tags)
+	}
+
+	if err := scheduler.Every(5).Minutes().When(true, (*Job).Hours).Do(task); err == nil {
+		t.Error("a fragment setting a second unit should fail")
+	}
+	other := func(j *Job) *Job { return scheduler.Every(1).Hour() }
+	if err := scheduler.Every(1).Apply(other).Do(task); err == nil {
+		t.Error("a fragment returning another job should fail")
+	}
+}
+
+func TestJob_DoErrors(t *testing.T) {
+	scheduler := NewScheduler()
+	tests := []struct {
+		name string
+		job  *Job
+		fn   interface{}
+	}{
+		{"bad time", scheduler.Every(1).Day().At("25:61"), task},
+		{"not a function", scheduler.Every(1).Day(), "task"},
+		{"nil function", scheduler.Every(1).Day(), nil},
+		{"bad weekday", scheduler.Every(1).Weekday(time.Weekday(7)), task},
+		{"unit interval", scheduler.Every(2).Minute(), task},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.job.Do(tt.fn); err == nil {
+				t.Error("Do() should return an error")
+			}
+			if tt.job.Err() == nil {
+				t.Error("Err() should return the builder error")
+			}
+		})
+	}
+
+	scheduler.RunPending()
+	if _, next := scheduler.NextRun(); next.IsZero() {
+		t.Error("NextRun should still answer with broken jobs present")
+	}
+}
+
+func TestJob_DoKeepsFirstError(t *testing.T) {
+	job := NewScheduler().Every(3).Day().At("x")
+	err := job.Do(task)
+	if err == nil || err.Error() != "Day() requires an interval of 1" {
+		t.Errorf("Do() = %v, want the first error of the chain", err)
+	}
+}
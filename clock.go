@@ -0,0 +1,46 @@
+package gocron
+
+import (
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+// SetClock - Read the time from c instead of the machine's clock, e.g. a
+// clock.Fake that tests advance by hand. Set it before adding jobs, the
+// ones already scheduled keep the next run the old clock gave them, and
+// before Start, a running loop keeps waiting on the clock it started with.
+// How long runs take is always measured on the machine's clock.
+func (s *Scheduler) SetClock(c clock.Clock) {
+	if c == nil {
+		c = clock.Real
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = c
+	for _, job := range s.jobs {
+		job.mu.Lock()
+		job.clock = c
+		job.mu.Unlock()
+	}
+}
+
+// The time on the scheduler's clock
+func (s *Scheduler) now() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clock.Now()
+}
+
+// Wait d on the scheduler's clock
+func (s *Scheduler) sleep(d time.Duration) {
+	s.mu.RLock()
+	c := s.clock
+	s.mu.RUnlock()
+	c.Sleep(d)
+}
+
+// The time on the job's clock, requires j.mu held
+func (j *Job) now() time.Time {
+	return j.clock.Now()
+}
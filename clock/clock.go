@@ -0,0 +1,49 @@
+// Package clock holds the time sources a gocron scheduler can read: the
+// real one it uses by default, and Fake, which only moves when a test
+// advances it.
+package clock
+
+import "time"
+
+// Clock - Where a scheduler gets the time from and how it waits
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	Sleep(d time.Duration)
+}
+
+// Ticker - Delivers the time on C every period, like time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	// Reset stops the ticker and restarts it with period d
+	Reset(d time.Duration)
+	Stop()
+}
+
+// Real - The clock of the machine, backed by the time package
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{t: time.NewTicker(d)}
+}
+
+// realTicker replaces its time.Ticker on Reset, which time.Ticker itself
+// only has from go1.15 on. C must be read again after Reset.
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r *realTicker) C() <-chan time.Time { return r.t.C }
+
+func (r *realTicker) Reset(d time.Duration) {
+	r.t.Stop()
+	r.t = time.NewTicker(d)
+}
+
+func (r *realTicker) Stop() { r.t.Stop() }
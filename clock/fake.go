@@ -0,0 +1,178 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake - A clock that stands still until Advance or Set moves it, for
+// testing schedules without waiting on them. Tickers fire and sleepers
+// wake as the time they wait for is reached.
+type Fake struct {
+	mu       sync.Mutex
+	now      time.Time
+	tickers  []*fakeTicker
+	sleepers []*sleeper
+	// closed whenever a ticker or sleeper starts waiting, see BlockUntil
+	waiting chan struct{}
+}
+
+// NewFake - A fake clock reading now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now, waiting: make(chan struct{})}
+}
+
+// Now - The fake time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance - Move the clock d ahead
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.set(f.now.Add(d))
+	f.mu.Unlock()
+}
+
+// Set - Move the clock to t, which must not be before Now
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	if t.After(f.now) {
+		f.set(t)
+	}
+	f.mu.Unlock()
+}
+
+// Sleep - Block until the clock has been moved d ahead
+func (f *Fake) Sleep(d time.Duration) {
+	f.mu.Lock()
+	if d <= 0 {
+		f.mu.Unlock()
+		return
+	}
+	s := &sleeper{until: f.now.Add(d), done: make(chan struct{})}
+	f.sleepers = append(f.sleepers, s)
+	f.notify()
+	f.mu.Unlock()
+	<-s.done
+}
+
+// NewTicker - A ticker firing each time the clock is moved past another
+// period d. Like time.Ticker it drops ticks the reader is not ready for.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTicker{f: f, c: make(chan time.Time, 1), period: d, next: f.now.Add(d)}
+	f.tickers = append(f.tickers, t)
+	f.notify()
+	return t
+}
+
+// BlockUntil - Wait until n tickers and sleepers are waiting on the
+// clock, e.g. until a scheduler loop is running before advancing it
+func (f *Fake) BlockUntil(n int) {
+	for {
+		f.mu.Lock()
+		waiting, ch := len(f.tickers)+len(f.sleepers), f.waiting
+		f.mu.Unlock()
+		if waiting >= n {
+			return
+		}
+		<-ch
+	}
+}
+
+// Move the time to now, firing what came due, requires f.mu held
+func (f *Fake) set(now time.Time) {
+	f.now = now
+	for _, t := range f.tickers {
+		t.fire(now)
+	}
+	kept := f.sleepers[:0]
+	for _, s := range f.sleepers {
+		if now.Before(s.until) {
+			kept = append(kept, s)
+		} else {
+			close(s.done)
+		}
+	}
+	for i := len(kept); i < len(f.sleepers); i++ {
+		f.sleepers[i] = nil
+	}
+	f.sleepers = kept
+}
+
+// Wake up BlockUntil, requires f.mu held
+func (f *Fake) notify() {
+	close(f.waiting)
+	f.waiting = make(chan struct{})
+}
+
+type sleeper struct {
+	until time.Time
+	done  chan struct{}
+}
+
+type fakeTicker struct {
+	f      *Fake
+	c      chan time.Time
+	period time.Duration
+	// when the ticker fires next, guarded by f.mu
+	next time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Reset")
+	}
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	t.period = d
+	t.next = t.f.now.Add(d)
+	if !t.ticking() {
+		t.f.tickers = append(t.f.tickers, t)
+	}
+	t.f.notify()
+}
+
+func (t *fakeTicker) Stop() {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	for i, other := range t.f.tickers {
+		if other == t {
+			t.f.tickers = append(t.f.tickers[:i], t.f.tickers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Whether the clock still fires the ticker, requires f.mu held
+func (t *fakeTicker) ticking() bool {
+	for _, other := range t.f.tickers {
+		if other == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Send now if the ticker came due, requires f.mu held
+func (t *fakeTicker) fire(now time.Time) {
+	if now.Before(t.next) {
+		return
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+	for !now.Before(t.next) {
+		t.next = t.next.Add(t.period)
+	}
+}
@@ -0,0 +1,91 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFake_Now(t *testing.T) {
+	f := NewFake(start)
+	f.Advance(time.Hour)
+	if got := f.Now(); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("Now() = %v after Advance, want %v", got, start.Add(time.Hour))
+	}
+	f.Set(start)
+	if got := f.Now(); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("Now() = %v, Set must not move the clock back", got)
+	}
+}
+
+func TestFake_Ticker(t *testing.T) {
+	f := NewFake(start)
+	ticker := f.NewTicker(time.Minute)
+	f.Advance(59 * time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("ticked before a period passed")
+	default:
+	}
+	f.Advance(time.Second)
+	if got := <-ticker.C(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("tick at %v, want %v", got, start.Add(time.Minute))
+	}
+
+	// like time.Ticker, ticks nobody reads are dropped
+	f.Advance(3 * time.Minute)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Error("got a second tick for one Advance")
+	default:
+	}
+
+	ticker.Reset(time.Hour)
+	f.Advance(time.Minute)
+	select {
+	case <-ticker.C():
+		t.Error("ticked a minute after Reset to an hour")
+	default:
+	}
+	ticker.Stop()
+	f.Advance(2 * time.Hour)
+	select {
+	case <-ticker.C():
+		t.Error("ticked after Stop")
+	default:
+	}
+}
+
+func TestFake_Sleep(t *testing.T) {
+	f := NewFake(start)
+	woke := make(chan time.Time)
+	go func() {
+		f.Sleep(time.Second)
+		woke <- f.Now()
+	}()
+	f.BlockUntil(1)
+	f.Advance(500 * time.Millisecond)
+	select {
+	case <-woke:
+		t.Fatal("woke up before the clock moved a second")
+	case <-time.After(10 * time.Millisecond):
+	}
+	f.Advance(500 * time.Millisecond)
+	if got := <-woke; !got.Equal(start.Add(time.Second)) {
+		t.Errorf("woke up at %v, want %v", got, start.Add(time.Second))
+	}
+	f.Sleep(0)
+}
+
+func TestReal(t *testing.T) {
+	ticker := Real.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	<-ticker.C()
+	ticker.Reset(time.Millisecond)
+	<-ticker.C()
+	if d := time.Since(Real.Now()); d < 0 || d > time.Second {
+		t.Errorf("Real.Now() is %v off the time package", d)
+	}
+}
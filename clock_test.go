@@ -0,0 +1,84 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestScheduler_SetClockSchedules(t *testing.T) {
+	scheduler := NewScheduler()
+	// a Wednesday
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	job := scheduler.Every(1).Monday().At("10:30").Loc(time.UTC)
+	job.Do(task)
+	if got, want := job.NextScheduledTime(), time.Date(2024, 5, 6, 10, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("first run %v, want %v", got, want)
+	}
+	if d, _ := job.TimeUntilNextRun(); d != 4*24*time.Hour+22*time.Hour+30*time.Minute {
+		t.Errorf("TimeUntilNextRun() = %v, want the time to Monday 10:30 on the fake clock", d)
+	}
+
+	fake.Set(time.Date(2024, 5, 6, 10, 30, 1, 0, time.UTC))
+	scheduler.RunPendingAndWait()
+	if job.RunCount() != 1 || !job.LastRun().Equal(fake.Now()) {
+		t.Fatalf("%d runs, last at %v, want one at %v", job.RunCount(), job.LastRun(), fake.Now())
+	}
+	if got, want := job.NextScheduledTime(), time.Date(2024, 5, 13, 10, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("run after %v, want %v", got, want)
+	}
+}
+
+func TestScheduler_SetClockLoop(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	ran := make(chan time.Time, 10)
+	job := scheduler.Every(1).Hour()
+	job.Do(func() { ran <- fake.Now() })
+
+	scheduler.Start()
+	defer scheduler.Stop()
+	fake.BlockUntil(1)
+	select {
+	case <-ran:
+		t.Fatal("ran before the fake clock moved")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// the loop sleeps at most maxWake, so minute steps never overshoot far
+	for i := 0; i < 2; i++ {
+		want := fake.Now().Add(time.Hour)
+		got := advanceUntil(t, fake, ran)
+		if got.Before(want) || got.After(want.Add(3*time.Minute)) {
+			t.Errorf("run %d at %v, want soon after %v", i, got, want)
+		}
+	}
+}
+
+// Step fake a minute at a time until a run is reported on ran
+func advanceUntil(t *testing.T, fake *clock.Fake, ran <-chan time.Time) time.Time {
+	t.Helper()
+	for i := 0; i < 2*60; i++ {
+		fake.Advance(time.Minute)
+		select {
+		case at := <-ran:
+			return at
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	t.Fatal("no run within two hours on the fake clock")
+	return time.Time{}
+}
+
+func TestScheduler_SetClockNil(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetClock(nil)
+	job := scheduler.Every(1).Minute()
+	job.Do(task)
+	if d, _ := job.TimeUntilNextRun(); d <= 0 || d > time.Minute {
+		t.Errorf("TimeUntilNextRun() = %v, want the machine's clock", d)
+	}
+}
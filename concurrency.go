@@ -0,0 +1,34 @@
+package gocron
+
+import "errors"
+
+// LimitMode - What a run does when the scheduler is at its limit of
+// concurrent runs, see SetMaxConcurrentJobs
+type LimitMode int
+
+const (
+	// LimitWait queues the run until another one returns
+	LimitWait LimitMode = iota
+	// LimitReschedule drops the run, the job keeps its next run. Dropped
+	// runs are counted by SkippedRuns.
+	LimitReschedule
+)
+
+// returned by the executor when LimitReschedule drops a run
+var errAtLimit = errors.New("the scheduler is at its limit of concurrent runs")
+
+// SetMaxConcurrentJobs - Run at most n jobs at a time across the
+// scheduler, e.g. s.SetMaxConcurrentJobs(2, gocron.LimitWait) so that a
+// burst of due jobs does not overwhelm what they call. n <= 0 lifts the
+// limit. Runs already started keep the limit they started under. Jobs
+// with an ordering key are not counted, their lane runs them one at a
+// time independently of the limit.
+func (s *Scheduler) SetMaxConcurrentJobs(n int, mode LimitMode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slots = nil
+	if n > 0 {
+		s.slots = make(chan struct{}, n)
+	}
+	s.limitMode = mode
+}
@@ -0,0 +1,109 @@
+package gocron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// Schedule n jobs due now that record how many run at once
+func burst(s *Scheduler, n int, took time.Duration) (peak func() int) {
+	var mu sync.Mutex
+	going, max := 0, 0
+	for i := 0; i < n; i++ {
+		s.Every(1).Hour().StartImmediately().Do(func() {
+			mu.Lock()
+			if going++; going > max {
+				max = going
+			}
+			mu.Unlock()
+			time.Sleep(took)
+			mu.Lock()
+			going--
+			mu.Unlock()
+		})
+	}
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return max
+	}
+}
+
+func TestScheduler_SetMaxConcurrentJobsWait(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetMaxConcurrentJobs(2, LimitWait)
+	peak := burst(scheduler, 10, 100*time.Millisecond)
+
+	begin := time.Now()
+	scheduler.RunPendingAndWait()
+	if p := peak(); p != 2 {
+		t.Errorf("%d jobs ran at once, want 2", p)
+	}
+	if took := time.Since(begin); took < 500*time.Millisecond {
+		t.Errorf("10 runs of 100ms two at a time took %v", took)
+	}
+	for _, job := range scheduler.Jobs() {
+		if job.RunCount() != 1 || job.SkippedRuns() != 0 {
+			t.Errorf("job ran %d times and skipped %d, want every job run once", job.RunCount(), job.SkippedRuns())
+		}
+	}
+}
+
+func TestScheduler_SetMaxConcurrentJobsReschedule(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetMaxConcurrentJobs(2, LimitReschedule)
+	peak := burst(scheduler, 10, 100*time.Millisecond)
+
+	scheduler.RunPendingAndWait()
+	if p := peak(); p != 2 {
+		t.Errorf("%d jobs ran at once, want 2", p)
+	}
+	ran, skipped := 0, 0
+	for _, job := range scheduler.Jobs() {
+		ran += job.RunCount()
+		skipped += int(job.SkippedRuns())
+		if d, _ := job.TimeUntilNextRun(); d < 59*time.Minute {
+			t.Errorf("next run in %v, want the skipped job pushed to its next slot", d)
+		}
+	}
+	if ran != 2 || skipped != 8 {
+		t.Errorf("%d runs and %d skipped, want 2 and 8", ran, skipped)
+	}
+
+	// lifting the limit lets all of them run
+	scheduler.SetMaxConcurrentJobs(0, LimitWait)
+	peak = burst(scheduler, 4, 100*time.Millisecond)
+	scheduler.RunPendingAndWait()
+	if p := peak(); p != 4 {
+		t.Errorf("%d jobs ran at once without a limit, want 4", p)
+	}
+}
+
+func TestScheduler_SetMaxConcurrentJobsLanes(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetMaxConcurrentJobs(2, LimitReschedule)
+	started, release := make(chan struct{}, 3), make(chan struct{})
+	var laned []*Job
+	for i := 0; i < 3; i++ {
+		job := scheduler.Every(1).Hour().OrderingKey("acme")
+		job.Do(stuckJob, started, release)
+		laned = append(laned, job)
+	}
+	for _, job := range laned {
+		scheduler.runNow(job)
+	}
+	<-started
+	free := scheduler.Every(1).Hour()
+	free.Do(task)
+	scheduler.runNow(free)
+	close(release)
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	for _, job := range append(laned, free) {
+		if job.RunCount() != 1 || job.SkippedRuns() != 0 {
+			t.Errorf("job ran %d times and skipped %d, want lane runs outside the limit", job.RunCount(), job.SkippedRuns())
+		}
+	}
+}
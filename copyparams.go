@@ -0,0 +1,165 @@
+package gocron
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// CopyParamsPerRun - Give every run its own deep copy of the params passed
+// to Do, so a run that appends to a slice or writes to a map param does
+// not change what later runs see. Slices, arrays, maps, pointers,
+// interfaces and exported struct fields are copied; channels and
+// functions are shared. Do returns an error if a param holds a struct
+// with unexported fields, which reflection cannot copy, or a cycle.
+func (j *Job) CopyParamsPerRun() *Job {
+	if !j.building("CopyParamsPerRun") {
+		return j
+	}
+	j.mu.Lock()
+	j.copyParams = true
+	j.mu.Unlock()
+	return j
+}
+
+// copyParams deep copies params for one run
+func copyParams(params []interface{}) ([]interface{}, error) {
+	copied := make([]interface{}, len(params))
+	for i, param := range params {
+		if param == nil {
+			continue
+		}
+		v, err := deepCopy(reflect.ValueOf(param), map[uintptr]bool{})
+		if err != nil {
+			return nil, errors.New("cannot copy param " + strconv.Itoa(i) + ": " + err.Error())
+		}
+		copied[i] = v.Interface()
+	}
+	return copied, nil
+}
+
+// deepCopy copies v, onPath holds the references being copied above it
+func deepCopy(v reflect.Value, onPath map[uintptr]bool) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type()), nil
+		}
+		p := v.Pointer()
+		if onPath[p] {
+			return reflect.Value{}, errors.New("cyclic " + v.Type().String())
+		}
+		onPath[p] = true
+		defer delete(onPath, p)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem, err := deepCopy(v.Elem(), onPath)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(elem)
+		return c, nil
+
+	case reflect.Slice:
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, err := deepCopy(v.Index(i), onPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			c.Index(i).Set(elem)
+		}
+		return c, nil
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			elem, err := deepCopy(v.Index(i), onPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			c.Index(i).Set(elem)
+		}
+		return c, nil
+
+	case reflect.Map:
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			elem, err := deepCopy(iter.Value(), onPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			c.SetMapIndex(iter.Key(), elem)
+		}
+		return c, nil
+
+	case reflect.Struct:
+		t := v.Type()
+		c := reflect.New(t).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				return reflect.Value{}, errors.New(t.String() + " has unexported field " + t.Field(i).Name)
+			}
+			field, err := deepCopy(v.Field(i), onPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			c.Field(i).Set(field)
+		}
+		return c, nil
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type()), nil
+		}
+		elem, err := deepCopy(v.Elem(), onPath)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(elem)
+		return c, nil
+	}
+	return v, nil
+}
+
+// SetDebug - Turn on self-diagnostics. The scheduler then warns through
+// its logger when a job that does not copy its params changed them, and
+// when the builder chain of a job created after SetDebug is continued on
+// another goroutine.
+func (s *Scheduler) SetDebug(on bool) {
+	s.mu.Lock()
+	s.debug = on
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) debugging() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.debug
+}
+
+// Warn when params no longer match the fingerprint taken when the job was
+// scheduled or last warned about. Params that cannot be fingerprinted are
+// not checked.
+func (j *Job) checkParams(params []interface{}) {
+	sum, err := FingerprintParams(params...)
+	if err != nil {
+		return
+	}
+	j.mu.Lock()
+	// jobs scheduled before debug mode was turned on have no fingerprint yet
+	changed := j.paramsSum != "" && sum != j.paramsSum
+	j.paramsSum = sum
+	j.mu.Unlock()
+	if changed {
+		j.scheduler.logf("job %s changed its params during a run, see CopyParamsPerRun", j.funcName())
+	}
+}
@@ -0,0 +1,129 @@
+package gocron
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type exportedOnly struct {
+	Names []string
+	Next  *exportedOnly
+}
+
+type withUnexported struct {
+	Name   string
+	secret int
+}
+
+type cyclic struct {
+	Self *cyclic
+}
+
+func TestCopyParams(t *testing.T) {
+	var nilMap map[string]int
+	params := []interface{}{
+		[][]int{{1, 2}, {3}},
+		map[string][]string{"a": {"x"}, "b": nil},
+		&exportedOnly{Names: []string{"n"}, Next: &exportedOnly{}},
+		[]interface{}{map[int]int{1: 1}, nil},
+		nilMap,
+		nil,
+		42,
+	}
+	copied, err := copyParams(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(copied, params) {
+		t.Fatalf("copy %#v differs from %#v", copied, params)
+	}
+
+	copied[0].([][]int)[0][0] = 100
+	copied[1].(map[string][]string)["a"][0] = "changed"
+	copied[2].(*exportedOnly).Next.Names = []string{"changed"}
+	copied[3].([]interface{})[0].(map[int]int)[1] = 100
+	if params[0].([][]int)[0][0] != 1 || params[1].(map[string][]string)["a"][0] != "x" ||
+		params[2].(*exportedOnly).Next.Names != nil || params[3].([]interface{})[0].(map[int]int)[1] != 1 {
+		t.Errorf("writing to the copy changed the original %#v", params)
+	}
+	if copied[4].(map[string]int) != nil || copied[5] != nil {
+		t.Error("nil params should stay nil")
+	}
+}
+
+func TestCopyParamsErrors(t *testing.T) {
+	loop := &cyclic{}
+	loop.Self = loop
+	tests := []struct {
+		name  string
+		param interface{}
+		want  string
+	}{
+		{"unexported field", withUnexported{Name: "x"}, "unexported field secret"},
+		{"unexported field behind a pointer", []*withUnexported{{}}, "unexported field secret"},
+		{"cycle", loop, "cyclic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := NewScheduler().Every(1).Second().CopyParamsPerRun()
+			err := job.Do(func(interface{}) {}, tt.param)
+			if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), "param 0") {
+				t.Errorf("Do() = %v, want an error naming param 0 and %q", err, tt.want)
+			}
+		})
+	}
+
+	// without copying the same params are accepted
+	if err := NewScheduler().Every(1).Second().Do(func(interface{}) {}, loop); err != nil {
+		t.Errorf("Do() without CopyParamsPerRun = %v", err)
+	}
+}
+
+func TestJob_CopyParamsPerRun(t *testing.T) {
+	scheduler := NewScheduler()
+	lengths := make(chan int, 3)
+	job := scheduler.Every(1).Second().CopyParamsPerRun()
+	job.Do(func(buf *[]string) {
+		*buf = append(*buf, "run")
+		lengths <- len(*buf)
+	}, &[]string{})
+
+	for i := 0; i < 3; i++ {
+		scheduler.RunAll()
+		if n := <-lengths; n != 1 {
+			t.Errorf("run %d saw %d entries, want 1: runs must not share the buffer", i, n)
+		}
+	}
+}
+
+func TestScheduler_DebugWarnsOnParamMutation(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+	scheduler.SetDebug(true)
+
+	var wg sync.WaitGroup
+	buf := []int{0}
+	scheduler.Every(1).Second().Do(func(b []int) {
+		b[0]++
+		wg.Done()
+	}, buf)
+
+	wg.Add(1)
+	scheduler.RunAll()
+	wg.Wait()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		logger.mu.Lock()
+		n := len(logger.lines)
+		logger.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("expected a warning about the changed params")
+}
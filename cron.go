@@ -0,0 +1,254 @@
+package gocron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unit of jobs scheduled with Cron
+const unitCron = "cron"
+
+// cronSchedule is a parsed cron expression, each field a bit set of the
+// values it matches
+type cronSchedule struct {
+	expr                                  string
+	second, minute, hour, dom, month, dow uint64
+	domAny, dowAny                        bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	// 7 is accepted for Sunday and folded onto 0
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// Cron - Schedule a new job with a five field cron expression:
+// minute, hour, day of month, month and day of week, e.g.
+// s.Cron("*/5 * * * *").Do(task) or s.Cron("0 9 * * MON-FRI").Do(task)
+// Fields take *, values, names, ranges, steps and comma separated lists.
+// An invalid expression is returned as an error by Do.
+//
+// Times are computed in the job's location. A time skipped by a DST change
+// does not fire, a time repeated by one fires once.
+func (s *Scheduler) Cron(expr string) *Job {
+	return s.cronJob(expr, false)
+}
+
+// CronWithSeconds - Like Cron, with a leading seconds field, e.g.
+// s.CronWithSeconds("0 30 10 * * 1-5").Do(task)
+func (s *Scheduler) CronWithSeconds(expr string) *Job {
+	return s.cronJob(expr, true)
+}
+
+// RunCron - Schedule fn to run on the five field cron expression expr,
+// returning an error for an invalid expression, e.g.
+// s.RunCron("0 9 * * MON-FRI", report)
+func (s *Scheduler) RunCron(expr string, fn func()) (*Job, error) {
+	if fn == nil {
+		return nil, errors.New("nil job function")
+	}
+	if _, err := parseCron(expr, false); err != nil {
+		return nil, err
+	}
+	return s.runHelper(s.Cron(expr), fn)
+}
+
+func (s *Scheduler) cronJob(expr string, seconds bool) *Job {
+	job := s.Every(1)
+	c, err := parseCron(expr, seconds)
+	if err != nil {
+		job.setErr(err)
+		return job
+	}
+	job.mu.Lock()
+	job.unit = unitCron
+	job.cron = c
+	job.mu.Unlock()
+	return job
+}
+
+func parseCron(expr string, seconds bool) (*cronSchedule, error) {
+	fail := func(reason string) error {
+		return errors.New("invalid cron expression " + strconv.Quote(expr) + ": " + reason)
+	}
+	fields := strings.Fields(expr)
+	want := 5
+	if seconds {
+		want = 6
+	} else {
+		fields = append([]string{"0"}, fields...)
+	}
+	if len(fields) != 6 {
+		return nil, fail("want " + strconv.Itoa(want) + " fields, got " + strconv.Itoa(len(strings.Fields(expr))))
+	}
+
+	c := &cronSchedule{expr: expr}
+	specs := []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{cronSecond, &c.second},
+		{cronMinute, &c.minute},
+		{cronHour, &c.hour},
+		{cronDom, &c.dom},
+		{cronMonth, &c.month},
+		{cronDow, &c.dow},
+	}
+	for i, spec := range specs {
+		bits, err := spec.field.parse(fields[i])
+		if err != nil {
+			return nil, fail(err.Error())
+		}
+		*spec.bits = bits
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	// like Vixie cron, a day field starting with * does not restrict the other
+	c.domAny = strings.HasPrefix(fields[3], "*") || fields[3] == "?"
+	c.dowAny = strings.HasPrefix(fields[5], "*") || fields[5] == "?"
+	if c.next(time.Now(), time.UTC).IsZero() {
+		return nil, fail("never fires")
+	}
+	return c, nil
+}
+
+// parse one field into a bit set
+func (f cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		rng, step := item, 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, errors.New(f.name + " step " + strconv.Quote(item[i+1:]) + " is not a positive number")
+			}
+			rng, step = item[:i], n
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.IndexByte(rng, '-') > 0:
+			i := strings.IndexByte(rng, '-')
+			var err error
+			if lo, err = f.value(rng[:i]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(rng[i+1:]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, errors.New(f.name + " range " + strconv.Quote(rng) + " is backwards")
+			}
+		default:
+			v, err := f.value(rng)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// a single value or name of the field
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("unknown " + f.name + " " + strconv.Quote(s))
+	}
+	if v < f.min || v > f.max {
+		return 0, errors.New(f.name + " " + s + " out of range " + strconv.Itoa(f.min) + "-" + strconv.Itoa(f.max))
+	}
+	return v, nil
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	// cron matches either day field when both are restricted
+	return dom || dow
+}
+
+// The first time after after matching the expression in loc, zero if
+// there is none in the next five years
+func (c *cronSchedule) next(after time.Time, loc *time.Location) time.Time {
+	t := after.In(loc).Truncate(time.Second).Add(time.Second)
+	// wall clock steps can land before t around DST changes, fall back
+	// to stepping the absolute time then
+	step := func(wall time.Time, d time.Duration) time.Time {
+		if wall.After(t) {
+			return wall
+		}
+		return t.Truncate(d).Add(d)
+	}
+	limit := t.Year() + 5
+	for t.Year() <= limit {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = step(time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc), 24*time.Hour)
+		case !c.dayMatches(t):
+			t = step(time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc), time.Hour)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = step(time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc), time.Hour)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = step(time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc), time.Minute)
+		case c.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Cron - Schedule a new job with a cron expression on the default scheduler
+func Cron(expr string) *Job {
+	return defaultScheduler().Cron(expr)
+}
+
+// RunCron - Schedule fn to run on the cron expression expr on the default scheduler
+func RunCron(expr string, fn func()) (*Job, error) {
+	return defaultScheduler().RunCron(expr, fn)
+}
+
+// CronWithSeconds - Schedule a new job with a six field cron expression on
+// the default scheduler
+func CronWithSeconds(expr string) *Job {
+	return defaultScheduler().CronWithSeconds(expr)
+}
@@ -0,0 +1,164 @@
+package gocron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCron_Errors(t *testing.T) {
+	cases := []struct {
+		expr    string
+		seconds bool
+		want    string
+	}{
+		{"* * * *", false, "want 5 fields, got 4"},
+		{"* * * * * *", false, "want 5 fields, got 6"},
+		{"* * * * *", true, "want 6 fields, got 5"},
+		{"60 * * * *", false, "minute 60 out of range 0-59"},
+		{"* 24 * * *", false, "hour 24 out of range 0-23"},
+		{"* * 0 * *", false, "day of month 0 out of range 1-31"},
+		{"* * * FOO *", false, `unknown month "FOO"`},
+		{"* * * * MON-", false, `unknown day of week ""`},
+		{"*/0 * * * *", false, `minute step "0" is not a positive number`},
+		{"30-10 * * * *", false, `minute range "30-10" is backwards`},
+		{"0 0 30 2 *", false, "never fires"},
+	}
+	for _, c := range cases {
+		_, err := parseCron(c.expr, c.seconds)
+		if err == nil || !strings.HasSuffix(err.Error(), c.want) {
+			t.Errorf("parseCron(%q) = %v, want an error ending in %q", c.expr, err, c.want)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	utc := func(s string) time.Time {
+		tm, err := time.Parse("2006-01-02 15:04:05", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	cases := []struct {
+		expr    string
+		seconds bool
+		after   string
+		want    string
+	}{
+		{"*/5 * * * *", false, "2024-01-31 23:58:30", "2024-02-01 00:00:00"},
+		{"0 0 31 * *", false, "2024-04-15 00:00:00", "2024-05-31 00:00:00"},
+		{"0 12 1 * *", false, "2024-01-31 13:00:00", "2024-02-01 12:00:00"},
+		{"0 0 29 2 *", false, "2024-03-01 00:00:00", "2028-02-29 00:00:00"},
+		{"30 9 * * MON,FRI", false, "2024-05-07 10:00:00", "2024-05-10 09:30:00"},
+		{"0 0 * * 7", false, "2024-05-07 10:00:00", "2024-05-12 00:00:00"},
+		{"0 0 1-7 * MON", false, "2024-05-02 00:00:00", "2024-05-03 00:00:00"},
+		{"0 0 1 jan-mar/2 *", false, "2024-01-02 00:00:00", "2024-03-01 00:00:00"},
+		{"0 30 10 * * 1-5", true, "2024-05-10 10:30:00", "2024-05-13 10:30:00"},
+		{"15,45 * * * * *", true, "2024-05-10 10:30:15", "2024-05-10 10:30:45"},
+	}
+	for _, c := range cases {
+		sched, err := parseCron(c.expr, c.seconds)
+		if err != nil {
+			t.Errorf("parseCron(%q): %v", c.expr, err)
+			continue
+		}
+		if got := sched.next(utc(c.after), time.UTC); !got.Equal(utc(c.want)) {
+			t.Errorf("%q after %s = %s, want %s", c.expr, c.after, got, c.want)
+		}
+	}
+}
+
+func TestCronSchedule_NextDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, berlin)
+	}
+	daily, _ := parseCron("30 2 * * *", false)
+
+	// 02:30 does not exist on the 31st of March
+	if got := daily.next(at(time.March, 30, 3, 0), berlin); !got.Equal(at(time.April, 1, 2, 30)) {
+		t.Errorf("spring forward: got %s, want the 1st of April", got)
+	}
+	// and happens twice on the 27th of October, the job fires once
+	first := daily.next(at(time.October, 27, 0, 0), berlin)
+	if !first.Equal(at(time.October, 27, 2, 30)) {
+		t.Fatalf("fall back: got %s, want the 27th of October", first)
+	}
+	if got := daily.next(first, berlin); !got.Equal(at(time.October, 28, 2, 30)) {
+		t.Errorf("fall back: got %s after %s, want the 28th of October", got, first)
+	}
+
+	hourly, _ := parseCron("0 * * * *", false)
+	prev := at(time.October, 27, 0, 0)
+	for i := 0; i < 5; i++ {
+		next := hourly.next(prev, berlin)
+		if !next.After(prev) || next.Sub(prev) > 2*time.Hour {
+			t.Fatalf("hourly job went from %s to %s", prev, next)
+		}
+		prev = next
+	}
+}
+
+func TestScheduler_Cron(t *testing.T) {
+	s := NewScheduler()
+	tokyo := time.FixedZone("JST", 9*60*60)
+	job := s.Cron("0 9 * * *").Loc(tokyo)
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	next := job.NextScheduledTime().In(tokyo)
+	if next.Hour() != 9 || next.Minute() != 0 || !next.After(time.Now()) || time.Until(next) > 24*time.Hour {
+		t.Errorf("next run %s, want the next 09:00 in Tokyo", next)
+	}
+
+	job.mu.Lock()
+	job.scheduleAfterRun(next, next)
+	after := job.nextRun
+	job.mu.Unlock()
+	if !after.Equal(next.AddDate(0, 0, 1)) {
+		t.Errorf("after a run at %s the next is %s, want a day later", next, after)
+	}
+
+	if err := s.Cron("0 9 * * *").At("10:00").Do(func() {}); err == nil {
+		t.Error("At on a cron job should fail")
+	}
+	if err := s.CronWithSeconds("0 9 * * *").Do(func() {}); err == nil {
+		t.Error("CronWithSeconds with five fields should fail")
+	}
+}
+
+func TestScheduler_RunCron(t *testing.T) {
+	s := NewScheduler()
+	job, err := s.RunCron("*/5 * * * *", task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := job.String(); !strings.HasPrefix(got, "[gocron.task] cron */5 * * * *, next ") {
+		t.Errorf("String() = %q", got)
+	}
+	if _, err := s.RunCron("*/5 * * *", task); err == nil {
+		t.Error("RunCron of a bad expression should fail")
+	}
+	if _, err := s.RunCron("*/5 * * * *", nil); err == nil {
+		t.Error("RunCron with a nil function should fail")
+	}
+	s.SetDuplicatePolicy(DuplicateReject)
+	if job, err := s.RunCron("*/5 * * * *", task); err == nil || job != nil {
+		t.Errorf("RunCron() = %v, %v, want the duplicate's error", job, err)
+	}
+	if n := len(s.Jobs()); n != 1 {
+		t.Errorf("failed helpers must not add jobs, have %d", n)
+	}
+
+	// a job failing in Do is not left behind half built
+	if _, err := s.runHelper(s.Every(1).Hour().DayOfTheMonth(3), task); err == nil {
+		t.Error("runHelper() with a builder error should fail")
+	}
+	if n, left := len(s.Jobs()), len(s.UnfinalizedJobs()); n != 1 || left != 0 {
+		t.Errorf("%d jobs and %d unfinalized after a failed Do, want the first job only", n, left)
+	}
+}
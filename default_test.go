@@ -0,0 +1,85 @@
+package gocron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetDefaultScheduler(t *testing.T) {
+	previous := DefaultScheduler()
+	defer SetDefaultScheduler(previous)
+	before := previous.Len()
+	own := NewScheduler()
+	SetDefaultScheduler(own)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	ChangeLoc(tokyo)
+	job := Every(1).Day().At("10:30").Tag("nightly")
+	job.Do(task)
+	if own.Len() != 1 || len(Jobs()) != 1 || Jobs()[0] != job {
+		t.Fatalf("the job was not added to the scheduler set as default")
+	}
+	if previous.Len() != before {
+		t.Errorf("the previous default scheduler got %d new jobs", previous.Len()-before)
+	}
+	if got := job.NextScheduledTime().Location(); got != tokyo {
+		t.Errorf("job location %v, want the default scheduler's %v", got, tokyo)
+	}
+	if got := NewScheduler().Every(1).Day().location(); got == tokyo {
+		t.Error("ChangeLoc changed the location of another scheduler's jobs")
+	}
+	if err := RunByTag("nightly"); err != nil {
+		t.Error(err)
+	}
+	if err := RemoveByTag("nightly"); err != nil {
+		t.Error(err)
+	}
+	if len(Jobs()) != 0 {
+		t.Errorf("RemoveByTag left %d jobs", len(Jobs()))
+	}
+
+	SetDefaultScheduler(nil)
+	if s := DefaultScheduler(); s == nil || s == own {
+		t.Error("SetDefaultScheduler(nil) did not give a new scheduler")
+	}
+}
+
+func TestStart_DefaultScheduler(t *testing.T) {
+	previous := DefaultScheduler()
+	defer SetDefaultScheduler(previous)
+	SetDefaultScheduler(NewScheduler())
+
+	var wg sync.WaitGroup
+	starts := make([]chan bool, 4)
+	for i := range starts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			starts[i] = Start()
+			Every(1).Hour().Do(task)
+		}(i)
+	}
+	wg.Wait()
+	for _, stopped := range starts[1:] {
+		if stopped != starts[0] {
+			t.Fatal("Start on a running default scheduler returned another channel")
+		}
+	}
+	if len(Jobs()) != len(starts) {
+		t.Errorf("got %d jobs, want %d", len(Jobs()), len(starts))
+	}
+
+	Stop()
+	if err := DefaultScheduler().Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	restarted := Start()
+	if restarted == starts[0] {
+		t.Error("Start after Stop returned the channel of the stopped run")
+	}
+	restarted <- true
+	if err := DefaultScheduler().Wait(time.Second); err != nil {
+		t.Error(err)
+	}
+}
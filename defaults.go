@@ -0,0 +1,88 @@
+package gocron
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// jobDefaults are the settings Every gives the jobs it creates, which the
+// jobs' own builders override
+type jobDefaults struct {
+	singleton       bool
+	singletonPolicy SingletonPolicy
+	timeout         time.Duration
+	tagsUnique      bool
+}
+
+// TagsUnique - Make a tag another job of the scheduler has already an
+// error for Tag, which Do then returns, so that tags name single jobs
+func (s *Scheduler) TagsUnique(unique bool) {
+	s.mu.Lock()
+	s.defaults.tagsUnique = unique
+	s.mu.Unlock()
+}
+
+// SingletonModeAll - Put the jobs created from now on in singleton mode
+// with policy, see Job.SingletonMode. A job's own SingletonMode call picks
+// its policy.
+func (s *Scheduler) SingletonModeAll(policy ...SingletonPolicy) {
+	p := SingletonSkip
+	if len(policy) > 0 {
+		p = policy[0]
+	}
+	s.mu.Lock()
+	s.defaults.singleton = true
+	s.defaults.singletonPolicy = p
+	s.mu.Unlock()
+}
+
+// SetDefaultTimeout - Give the jobs created from now on a Timeout of d, 0
+// for none. A job's own Timeout call overrides it.
+func (s *Scheduler) SetDefaultTimeout(d time.Duration) error {
+	if d < 0 {
+		return errors.New("default timeout must not be negative, got " + d.String())
+	}
+	s.mu.Lock()
+	s.defaults.timeout = d
+	s.mu.Unlock()
+	return nil
+}
+
+// SetDefaultLocation - Set the time location of the jobs created from now
+// on, the same as ChangeLoc. A job's own Loc call overrides it.
+func (s *Scheduler) SetDefaultLocation(loc *time.Location) {
+	s.ChangeLoc(loc)
+}
+
+// Give a job created by Every the defaults, s.mu must be held
+func (s *Scheduler) applyDefaults(j *Job) {
+	d := s.defaults
+	j.singleton = d.singleton
+	j.singletonPolicy = d.singletonPolicy
+	j.timeout = d.timeout
+}
+
+// With TagsUnique, an error for the first of tags a job other than j has,
+// s.mu must be held
+func (s *Scheduler) tagsTaken(j *Job, tags []string) error {
+	if !s.defaults.tagsUnique {
+		return nil
+	}
+	for _, job := range s.jobs {
+		if job == j {
+			continue
+		}
+		job.mu.Lock()
+		removed := job.removed
+		for _, tag := range tags {
+			if !removed && job.hasTag(tag) {
+				id := job.id
+				job.mu.Unlock()
+				return errors.New("tag " + strconv.Quote(tag) + " is taken by job " + strconv.FormatUint(id, 10))
+			}
+		}
+		job.mu.Unlock()
+	}
+	return nil
+}
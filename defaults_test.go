@@ -0,0 +1,99 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduler_SingletonModeAll(t *testing.T) {
+	scheduler := NewScheduler()
+	before := scheduler.Every(1).Hour()
+	scheduler.SingletonModeAll()
+	plain := scheduler.Every(1).Hour()
+	queued := scheduler.Every(1).Hour().SingletonMode(SingletonQueueOne)
+	cron := scheduler.Cron("0 * * * *")
+
+	for _, c := range []struct {
+		job       *Job
+		singleton bool
+		policy    SingletonPolicy
+	}{
+		{before, false, SingletonSkip},
+		{plain, true, SingletonSkip},
+		{queued, true, SingletonQueueOne},
+		{cron, true, SingletonSkip},
+	} {
+		c.job.mu.Lock()
+		if c.job.singleton != c.singleton || c.job.singletonPolicy != c.policy {
+			t.Errorf("%s: singleton %v with policy %d, want %v with %d", schedule(c.job), c.job.singleton, c.job.singletonPolicy, c.singleton, c.policy)
+		}
+		c.job.mu.Unlock()
+	}
+}
+
+func TestScheduler_SetDefaultTimeout(t *testing.T) {
+	scheduler := NewScheduler()
+	if err := scheduler.SetDefaultTimeout(-time.Second); err == nil {
+		t.Error("SetDefaultTimeout() with a negative timeout should fail")
+	}
+	if err := scheduler.SetDefaultTimeout(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	job := scheduler.EveryDuration(time.Hour)
+	own := scheduler.Every(1).Hour().Timeout(time.Second)
+	scheduler.SetDefaultTimeout(0)
+	none := scheduler.Every(1).Hour()
+
+	for _, c := range []struct {
+		job  *Job
+		want time.Duration
+	}{{job, time.Minute}, {own, time.Second}, {none, 0}} {
+		c.job.mu.Lock()
+		if c.job.timeout != c.want {
+			t.Errorf("%s: timeout %v, want %v", schedule(c.job), c.job.timeout, c.want)
+		}
+		c.job.mu.Unlock()
+	}
+}
+
+func TestScheduler_SetDefaultLocation(t *testing.T) {
+	scheduler := NewScheduler()
+	tokyo := time.FixedZone("Tokyo", 9*3600)
+	scheduler.SetDefaultLocation(tokyo)
+	job := scheduler.Every(1).Day().At("09:00")
+	job.Do(task)
+	own := scheduler.Every(1).Day().At("09:00").Loc(time.UTC)
+	own.Do(task)
+
+	if got := job.NextScheduledTime().In(tokyo); got.Hour() != 9 {
+		t.Errorf("run at %v, want 09:00 in the default location", got)
+	}
+	if got := own.NextScheduledTime().In(time.UTC); got.Hour() != 9 {
+		t.Errorf("run at %v, want 09:00 UTC from the job's own Loc", got)
+	}
+}
+
+func TestScheduler_TagsUnique(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.TagsUnique(true)
+	first := scheduler.Every(1).Hour().Tag("billing", "svc")
+	if err := first.Do(task); err != nil {
+		t.Fatal(err)
+	}
+	first.Tag("billing")
+	if tags := first.Tags(); len(tags) != 2 {
+		t.Errorf("Tags() = %v, a job may repeat its own tag", tags)
+	}
+	if err := scheduler.Every(1).Hour().Tag("svc").Do(task); err == nil {
+		t.Error("Do() with a tag another job has should fail")
+	}
+
+	first.RemoveSelf()
+	if err := scheduler.Every(1).Hour().Tag("svc").Do(task); err != nil {
+		t.Errorf("the tag of a removed job is free again, got %v", err)
+	}
+	scheduler.TagsUnique(false)
+	if err := scheduler.Every(1).Hour().Tag("svc").Do(task); err != nil {
+		t.Errorf("Do() without TagsUnique failed: %v", err)
+	}
+}
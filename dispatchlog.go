@@ -0,0 +1,79 @@
+package gocron
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// DispatchLogSize is how many RunPending passes RecentDispatchPasses keeps
+const DispatchLogSize = 32
+
+// Reasons a due job was not run
+const (
+	// SkipNotScheduled - Do was never called successfully on the job
+	SkipNotScheduled = "not scheduled"
+	// SkipPaused - the job or its scheduler is paused
+	SkipPaused = "paused"
+	// SkipRunning - the job is in SingletonMode and its previous run is going
+	SkipRunning = "still running"
+	// SkipLimit - the scheduler was at its limit of concurrent runs with
+	// LimitReschedule, see SetMaxConcurrentJobs
+	SkipLimit = "at concurrency limit"
+)
+
+// DispatchPass - What one RunPending pass looked at and decided
+type DispatchPass struct {
+	Start    time.Time
+	Duration time.Duration
+	// Shadow is set when the pass only recorded runs, see SetShadowMode
+	Shadow bool
+
+	// Scanned jobs, of which Due were due
+	Scanned int
+	Due     int
+
+	Dispatched []*Job
+	Skipped    []SkippedRun
+}
+
+// SkippedRun - A due job the pass did not run, and why
+type SkippedRun struct {
+	Job    *Job
+	Reason string
+}
+
+// dispatchLog is a ring of the latest passes. Appending takes no lock:
+// each pass claims a slot by bumping next and stores itself atomically.
+type dispatchLog struct {
+	next  uint64
+	slots [DispatchLogSize]atomic.Value
+}
+
+func (l *dispatchLog) record(p *DispatchPass) {
+	n := atomic.AddUint64(&l.next, 1)
+	l.slots[(n-1)%DispatchLogSize].Store(p)
+}
+
+// recent returns up to n passes, newest first
+func (l *dispatchLog) recent(n int) []DispatchPass {
+	next := atomic.LoadUint64(&l.next)
+	if n > DispatchLogSize {
+		n = DispatchLogSize
+	}
+	if uint64(n) > next {
+		n = int(next)
+	}
+	passes := make([]DispatchPass, 0, n)
+	for i := uint64(1); i <= uint64(n); i++ {
+		if p, _ := l.slots[(next-i)%DispatchLogSize].Load().(*DispatchPass); p != nil {
+			passes = append(passes, *p)
+		}
+	}
+	return passes
+}
+
+// RecentDispatchPasses - The last n RunPending passes, newest first, at
+// most DispatchLogSize of them
+func (s *Scheduler) RecentDispatchPasses(n int) []DispatchPass {
+	return s.passes.recent(n)
+}
@@ -0,0 +1,82 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduler_RecentDispatchPasses(t *testing.T) {
+	scheduler := NewScheduler()
+	paused := scheduler.Every(1).Minute()
+	paused.Do(func() {})
+	paused.Pause()
+	ready := scheduler.Every(1).Minute()
+	ready.Do(func() {})
+	scheduler.Every(5).Minutes() // never finalized with Do
+	scheduler.Every(1).Hour().Do(func() {})
+
+	past := time.Now().Add(-time.Second)
+	paused.nextRun = past
+	ready.nextRun = past
+	scheduler.RunPending()
+
+	passes := scheduler.RecentDispatchPasses(5)
+	if len(passes) != 1 {
+		t.Fatalf("got %d passes, want 1", len(passes))
+	}
+	pass := passes[0]
+	if pass.Scanned != 4 || pass.Due != 3 {
+		t.Errorf("scanned %d and %d due, want 4 and 3", pass.Scanned, pass.Due)
+	}
+	if len(pass.Dispatched) != 1 || pass.Dispatched[0] != ready {
+		t.Errorf("dispatched %v, want only the ready job", pass.Dispatched)
+	}
+	reasons := map[*Job]string{}
+	for _, skipped := range pass.Skipped {
+		reasons[skipped.Job] = skipped.Reason
+	}
+	if reasons[paused] != SkipPaused || len(reasons) != 2 {
+		t.Errorf("skipped %v, want the paused and the unscheduled job", pass.Skipped)
+	}
+}
+
+func TestDispatchLog_Wraps(t *testing.T) {
+	var l dispatchLog
+	for i := 0; i < DispatchLogSize+5; i++ {
+		l.record(&DispatchPass{Scanned: i})
+	}
+	passes := l.recent(DispatchLogSize + 10)
+	if len(passes) != DispatchLogSize {
+		t.Fatalf("got %d passes, want %d", len(passes), DispatchLogSize)
+	}
+	if passes[0].Scanned != DispatchLogSize+4 || passes[DispatchLogSize-1].Scanned != 5 {
+		t.Errorf("passes should run newest first from %d to 5, got %d to %d", DispatchLogSize+4, passes[0].Scanned, passes[DispatchLogSize-1].Scanned)
+	}
+}
+
+func TestScheduler_RecentDispatchPassesLimit(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetMaxConcurrentJobs(1, LimitReschedule)
+	release := make(chan struct{})
+	first := scheduler.Every(1).Minute()
+	first.Do(func() { <-release })
+	second := scheduler.Every(1).Minute()
+	second.Do(func() {})
+
+	past := time.Now().Add(-time.Second)
+	first.nextRun = past
+	second.nextRun = past.Add(time.Millisecond)
+	scheduler.RunPending()
+	close(release)
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	pass := scheduler.RecentDispatchPasses(1)[0]
+	if len(pass.Dispatched) != 1 || pass.Dispatched[0] != first {
+		t.Errorf("dispatched %v, want only the job that got the slot", pass.Dispatched)
+	}
+	if len(pass.Skipped) != 1 || pass.Skipped[0].Job != second || pass.Skipped[0].Reason != SkipLimit {
+		t.Errorf("skipped %v, want the second job at the limit", pass.Skipped)
+	}
+}
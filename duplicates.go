@@ -0,0 +1,93 @@
+package gocron
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// the ID of the latest job created, see ID
+var lastJobID uint64
+
+// DuplicatePolicy - Whether Do accepts a job identical to one already
+// scheduled, see SetDuplicatePolicy
+type DuplicatePolicy int
+
+const (
+	// DuplicateAllow schedules identical jobs side by side, the default
+	DuplicateAllow DuplicatePolicy = iota
+	// DuplicateReject makes Do fail for a job with the schedule, time
+	// location, function and params of one already scheduled, and removes
+	// the rejected job from the scheduler
+	DuplicateReject
+)
+
+// SetDuplicatePolicy - Set whether Do accepts identical jobs, e.g. so
+// that registering the jobs again on a config reload adds none twice:
+//
+//	s.SetDuplicatePolicy(gocron.DuplicateReject)
+//	s.Every(1).Hour().Do(sync, "users") // fails the second time
+func (s *Scheduler) SetDuplicatePolicy(p DuplicatePolicy) {
+	s.mu.Lock()
+	s.duplicates = p
+	s.mu.Unlock()
+}
+
+// ID - The job's ID, unique among the jobs created in the process
+func (j *Job) ID() uint64 {
+	return j.id
+}
+
+// RemoveByID - Remove the job with the given ID, reporting whether it was scheduled
+func (s *Scheduler) RemoveByID(id uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeMatching(func(job *Job) bool { return job.id == id }, false) > 0
+}
+
+// ScheduleExists - Whether a job running fn with params is scheduled,
+// whatever its schedule
+func (s *Scheduler) ScheduleExists(fn interface{}, params ...interface{}) bool {
+	name := getFunctionName(fn)
+	params, _ = revealSecrets(params)
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		found := !job.removed && job.jobFunc == name && sameParams(job.params, params)
+		job.mu.Unlock()
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// Fail Do of j with function name and params if the scheduler rejects
+// duplicates and holds a job identical to it, removing j from the
+// scheduler. Requires s.mu held, so that no identical job is finalized
+// between the check and j's.
+func (s *Scheduler) rejectDuplicate(j *Job, name string, params []interface{}) error {
+	if s.duplicates != DuplicateReject {
+		return nil
+	}
+	j.mu.Lock()
+	schedule, loc := j.describe(), j.location()
+	j.mu.Unlock()
+	for _, job := range s.jobs {
+		if job == j {
+			continue
+		}
+		job.mu.Lock()
+		same := !job.removed && job.jobFunc == name && sameParams(job.params, params) &&
+			job.describe() == schedule && job.location() == loc
+		job.mu.Unlock()
+		if same {
+			s.removeLocked(j)
+			return errors.New("Do() duplicates job " + strconv.FormatUint(job.id, 10) + ", " + schedule + " -> " + shortFuncName(name))
+		}
+	}
+	return nil
+}
+
+func sameParams(a, b []interface{}) bool {
+	return len(a) == 0 && len(b) == 0 || reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,103 @@
+package gocron
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func greet(name string) {}
+
+func TestScheduler_RemoveByID(t *testing.T) {
+	scheduler := NewScheduler()
+	alice := scheduler.Every(1).Hour()
+	bob := scheduler.Every(1).Hour()
+	alice.Do(greet, "alice")
+	bob.Do(greet, "bob")
+	if alice.ID() == bob.ID() || alice.ID() == 0 {
+		t.Fatalf("IDs %d and %d, want distinct ones", alice.ID(), bob.ID())
+	}
+	if p := alice.Params(); len(p) != 1 || p[0] != "alice" {
+		t.Errorf("Params() = %v, the second job overwrote the first one's", p)
+	}
+
+	if !scheduler.RemoveByID(alice.ID()) {
+		t.Fatal("RemoveByID did not find the job")
+	}
+	if scheduler.RemoveByID(alice.ID()) {
+		t.Error("RemoveByID removed a job twice")
+	}
+	if jobs := scheduler.Jobs(); len(jobs) != 1 || jobs[0] != bob {
+		t.Errorf("Jobs() = %v, want only the other job", jobs)
+	}
+	if scheduler.ScheduleExists(greet, "alice") || !scheduler.ScheduleExists(greet, "bob") {
+		t.Error("ScheduleExists does not match the jobs left")
+	}
+	if scheduler.ScheduleExists(greet) || scheduler.ScheduleExists(task) {
+		t.Error("ScheduleExists matched other params or another function")
+	}
+}
+
+func TestScheduler_DuplicatePolicy(t *testing.T) {
+	scheduler := NewScheduler()
+	for i := 0; i < 2; i++ {
+		if err := scheduler.Every(1).Hour().Do(greet, "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scheduler.Clear()
+	scheduler.SetDuplicatePolicy(DuplicateReject)
+	if err := scheduler.Every(1).Hour().Do(greet, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	dup := scheduler.Every(1).Hour()
+	err := dup.Do(greet, Secret("alice"))
+	if err == nil || !strings.Contains(err.Error(), "duplicates job") {
+		t.Errorf("Do() = %v, want the identical job rejected", err)
+	}
+	// any difference makes a new job
+	for _, job := range []*Job{
+		scheduler.Every(2).Hours(),
+		scheduler.Every(1).Hour().Loc(time.UTC),
+	} {
+		if err := job.Do(greet, "alice"); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := scheduler.Every(1).Hour().Do(greet, "bob"); err != nil {
+		t.Error(err)
+	}
+	if n := len(scheduler.Jobs()); n != 4 {
+		t.Errorf("%d jobs scheduled, want 4", n)
+	}
+	for _, job := range scheduler.Jobs() {
+		if job == dup {
+			t.Error("the rejected job was left in the scheduler")
+		}
+	}
+	if jobs := scheduler.UnfinalizedJobs(); len(jobs) != 0 {
+		t.Errorf("UnfinalizedJobs() = %v, want none", jobs)
+	}
+}
+
+func TestScheduler_DuplicatePolicyConcurrent(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetDuplicatePolicy(DuplicateReject)
+	for i := 0; i < 50; i++ {
+		scheduler.Clear()
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				scheduler.Every(1).Hour().Do(greet, "alice")
+			}()
+		}
+		wg.Wait()
+		if n := len(scheduler.Jobs()); n != 1 {
+			t.Fatalf("%d identical jobs scheduled concurrently, want 1", n)
+		}
+	}
+}
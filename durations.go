@@ -0,0 +1,126 @@
+package gocron
+
+import (
+	"math"
+	"time"
+)
+
+// Run durations are counted in fixed exponential buckets: the first holds
+// runs up to 1ms, each of the next 30 ends durationRatio times later than
+// the one before, the 31st at 5m, and the last holds anything longer.
+const (
+	durationBuckets = 32
+	durationFirst   = time.Millisecond
+	durationLast    = 5 * time.Minute
+)
+
+// The upper bounds of all buckets but the last
+var durationBounds = func() (b [durationBuckets - 1]time.Duration) {
+	ratio := math.Pow(float64(durationLast)/float64(durationFirst), 1/float64(len(b)-1))
+	for i := range b {
+		b[i] = time.Duration(float64(durationFirst) * math.Pow(ratio, float64(i)))
+	}
+	b[len(b)-1] = durationLast
+	return b
+}()
+
+// durationStats summarizes the run durations of one job in a fixed 152
+// bytes, however many runs it has
+type durationStats struct {
+	counts [durationBuckets]uint32
+	count  uint64
+	sum    time.Duration
+	max    time.Duration
+}
+
+func (s *durationStats) observe(d time.Duration) {
+	i := 0
+	for i < len(durationBounds) && d > durationBounds[i] {
+		i++
+	}
+	if s.counts[i] < math.MaxUint32 {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += d
+	if d > s.max {
+		s.max = d
+	}
+}
+
+// The q quantile, 0 < q <= 1, interpolated geometrically within its bucket
+func (s *durationStats) quantile(q float64) time.Duration {
+	var total uint64
+	for _, c := range s.counts {
+		total += uint64(c)
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := math.Ceil(q * float64(total))
+	var seen float64
+	for i, c := range s.counts {
+		if c == 0 || seen+float64(c) < rank {
+			seen += float64(c)
+			continue
+		}
+		frac := (rank - seen) / float64(c)
+		var est time.Duration
+		switch {
+		case i == 0:
+			est = time.Duration(frac * float64(durationFirst))
+		case i == len(durationBounds):
+			lo := float64(durationLast)
+			est = time.Duration(lo + frac*(float64(s.max)-lo))
+		default:
+			lo, hi := float64(durationBounds[i-1]), float64(durationBounds[i])
+			est = time.Duration(lo * math.Pow(hi/lo, frac))
+		}
+		if est > s.max {
+			est = s.max
+		}
+		return est
+	}
+	return s.max
+}
+
+// DurationSummary - How long a job's runs took. The quantiles are
+// estimates, off by at most a factor of about 1.5 as they come from
+// fixed buckets; Count, Sum and Max are exact.
+type DurationSummary struct {
+	Count uint64
+	Sum   time.Duration
+	Max   time.Duration
+
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+}
+
+// Mean - The average run duration, zero before the first run
+func (s DurationSummary) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / time.Duration(s.Count)
+}
+
+// DurationSummary - The durations of the job's runs so far, panicked runs
+// included. Each job keeps them in a fixed 152 bytes, so memory does not
+// grow with the number of runs.
+func (j *Job) DurationSummary() DurationSummary {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.durations.summary()
+}
+
+func (s *durationStats) summary() DurationSummary {
+	return DurationSummary{
+		Count: s.count,
+		Sum:   s.sum,
+		Max:   s.max,
+		P50:   s.quantile(0.5),
+		P95:   s.quantile(0.95),
+		P99:   s.quantile(0.99),
+	}
+}
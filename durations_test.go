@@ -0,0 +1,96 @@
+package gocron
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestDurationStats_Accuracy(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	dists := map[string]func() time.Duration{
+		"uniform 1ms-1s": func() time.Duration {
+			return time.Millisecond + time.Duration(rnd.Int63n(int64(time.Second)))
+		},
+		"exponential mean 200ms": func() time.Duration {
+			return time.Duration(rnd.ExpFloat64() * float64(200*time.Millisecond))
+		},
+		"lognormal median 50ms": func() time.Duration {
+			return time.Duration(math.Exp(rnd.NormFloat64()) * float64(50*time.Millisecond))
+		},
+		"bimodal 5ms and 2m": func() time.Duration {
+			if rnd.Intn(10) == 0 {
+				return 2*time.Minute + time.Duration(rnd.Int63n(int64(time.Second)))
+			}
+			return 5*time.Millisecond + time.Duration(rnd.Int63n(int64(time.Millisecond)))
+		},
+	}
+	for name, next := range dists {
+		var stats durationStats
+		samples := make([]time.Duration, 10000)
+		var sum time.Duration
+		for i := range samples {
+			samples[i] = next()
+			sum += samples[i]
+			stats.observe(samples[i])
+		}
+		sort.Slice(samples, func(a, b int) bool { return samples[a] < samples[b] })
+		got := stats.summary()
+		if got.Count != 10000 || got.Sum != sum || got.Max != samples[len(samples)-1] {
+			t.Errorf("%s: count %d, sum %v, max %v; want exact values", name, got.Count, got.Sum, got.Max)
+		}
+		for _, q := range []struct {
+			q   float64
+			est time.Duration
+		}{{0.5, got.P50}, {0.95, got.P95}, {0.99, got.P99}} {
+			exact := samples[int(math.Ceil(q.q*float64(len(samples))))-1]
+			if ratio := float64(q.est) / float64(exact); ratio < 1/1.25 || ratio > 1.25 {
+				t.Errorf("%s: p%v estimated %v, exact %v", name, q.q*100, q.est, exact)
+			}
+		}
+	}
+}
+
+func TestDurationStats_Footprint(t *testing.T) {
+	if size := unsafe.Sizeof(durationStats{}); size != 152 {
+		t.Errorf("durationStats takes %d bytes, documented as 152", size)
+	}
+	var stats durationStats
+	before := unsafe.Sizeof(stats)
+	for i := 0; i < 100000; i++ {
+		stats.observe(time.Duration(i) * time.Microsecond)
+	}
+	if unsafe.Sizeof(stats) != before {
+		t.Error("the summary grew with runs")
+	}
+	if stats.quantile(1) != stats.max || (&durationStats{}).quantile(0.5) != 0 {
+		t.Error("quantile bounds are off")
+	}
+}
+
+func TestJob_DurationSummary(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Hour()
+	job.Do(func() { time.Sleep(20 * time.Millisecond) })
+	if s := job.DurationSummary(); s.Count != 0 || s.Mean() != 0 {
+		t.Errorf("summary before any run %+v", s)
+	}
+	runAllAndWait(t, scheduler)
+	runAllAndWait(t, scheduler)
+
+	s := job.DurationSummary()
+	if s.Count != 2 || s.Max < 20*time.Millisecond || s.Mean() < 20*time.Millisecond || s.P50 > s.Max {
+		t.Errorf("summary %+v, want two runs of at least 20ms", s)
+	}
+	var status expvarStatus
+	if err := json.Unmarshal([]byte(schedulerVar{scheduler}.String()), &status); err != nil {
+		t.Fatal(err)
+	}
+	if d := status.Jobs[0].Duration; d == nil || d.Count != 2 || d.Max != s.Max.Seconds() {
+		t.Errorf("expvar duration %+v, want the job's summary", d)
+	}
+}
@@ -0,0 +1,64 @@
+package gocron
+
+import "time"
+
+// SetEventListeners - Call before as each run of the job starts and after
+// once it returns, with how long the call took, e.g. for metrics or
+// tracing. Both run on the run's goroutine, before runs the function and
+// after it, so they only hold up this job: its later runs when it is in
+// SingletonMode or has an ordering key, never other jobs. after is also
+// called for runs that panicked, and is called before OnError, OnSuccess
+// and the scheduler's error handler, which are the hooks for returned
+// errors. Either function may be nil.
+func (j *Job) SetEventListeners(before func(*Job), after func(*Job, time.Duration)) *Job {
+	j.mu.Lock()
+	j.beforeRun, j.afterRun = before, after
+	j.mu.Unlock()
+	return j
+}
+
+// SetEventListeners - Call before and after around every run of the
+// scheduler's jobs, in addition to each job's own listeners, which are
+// called closer to the run: the scheduler's before first and its after
+// last. See Job.SetEventListeners.
+func (s *Scheduler) SetEventListeners(before func(*Job), after func(*Job, time.Duration)) {
+	s.mu.Lock()
+	s.beforeRun, s.afterRun = before, after
+	s.mu.Unlock()
+}
+
+// Call the before listeners of a run that is starting
+func (j *Job) runStarting() {
+	j.mu.Lock()
+	own, s := j.beforeRun, j.scheduler
+	j.mu.Unlock()
+	if s != nil {
+		s.mu.RLock()
+		before := s.beforeRun
+		s.mu.RUnlock()
+		if before != nil {
+			before(j)
+		}
+	}
+	if own != nil {
+		own(j)
+	}
+}
+
+// Call the after listeners of a run that returned after took
+func (j *Job) runReturned(took time.Duration) {
+	j.mu.Lock()
+	own, s := j.afterRun, j.scheduler
+	j.mu.Unlock()
+	if own != nil {
+		own(j, took)
+	}
+	if s != nil {
+		s.mu.RLock()
+		after := s.afterRun
+		s.mu.RUnlock()
+		if after != nil {
+			after(j, took)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package gocron
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJob_SetEventListeners(t *testing.T) {
+	scheduler := NewScheduler()
+	var mu sync.Mutex
+	var calls []string
+	var took time.Duration
+	record := func(s string) {
+		mu.Lock()
+		calls = append(calls, s)
+		mu.Unlock()
+	}
+	scheduler.SetEventListeners(
+		func(*Job) { record("scheduler before") },
+		func(_ *Job, d time.Duration) { record("scheduler after") },
+	)
+	job := scheduler.Every(1).Hour().OnError(func(error) { record("error") })
+	job.SetEventListeners(
+		func(j *Job) {
+			if j != job {
+				t.Error("before got another job")
+			}
+			record("before")
+		},
+		func(_ *Job, d time.Duration) {
+			took = d
+			record("after")
+		},
+	)
+	job.Do(func() error {
+		record("run")
+		time.Sleep(20 * time.Millisecond)
+		return errors.New("failed")
+	})
+	runAllAndWait(t, scheduler)
+
+	want := []string{"scheduler before", "before", "run", "after", "scheduler after", "error"}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != len(want) {
+		t.Fatalf("calls %q, want %q", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls %q, want %q", calls, want)
+		}
+	}
+	if took < 20*time.Millisecond || took > time.Second {
+		t.Errorf("after got %v, want the run's duration", took)
+	}
+}
+
+func TestJob_EventListenersPanicAndBlocking(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetLogger(&recordingLogger{})
+	release := make(chan struct{})
+	afters := make(chan string, 2)
+	slow := scheduler.Every(1).Hour().SetEventListeners(func(*Job) { <-release }, nil)
+	slow.Do(task)
+	panicky := scheduler.Every(1).Hour().SetEventListeners(nil, func(*Job, time.Duration) { afters <- "panicked" })
+	panicky.Do(func() { panic("boom") })
+
+	scheduler.RunAll()
+	select {
+	case got := <-afters:
+		if got != "panicked" {
+			t.Errorf("after got %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Error("a blocked listener of one job held up another job")
+	}
+	close(release)
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,139 @@
+package gocron
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestScheduler_EveryDuration(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	var mu sync.Mutex
+	runs := 0
+	job := scheduler.EveryDuration(100 * time.Millisecond)
+	if err := job.Do(func() {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := job.TimeUntilNextRun(); d != 100*time.Millisecond {
+		t.Errorf("first run in %v, want 100ms", d)
+	}
+
+	for i := 0; i < 10; i++ {
+		fake.Advance(101 * time.Millisecond)
+		scheduler.RunPendingAndWait()
+	}
+	mu.Lock()
+	n := runs
+	mu.Unlock()
+	if n != 10 {
+		t.Errorf("got %d runs in a second, want 10", n)
+	}
+
+	if err := scheduler.EveryDuration(0).Do(func() {}); err == nil {
+		t.Error("EveryDuration(0) should fail")
+	}
+}
+
+func TestJob_NoDrift(t *testing.T) {
+	scheduler := NewScheduler()
+	hourly := scheduler.EveryDuration(time.Hour)
+	daily := scheduler.Every(1).Day().At("10:30").Loc(time.UTC)
+	for _, job := range []*Job{hourly, daily} {
+		if err := job.Do(task); err != nil {
+			t.Fatal(err)
+		}
+		first := job.nextRun
+		job.mu.Lock()
+		for n := 1; n <= 100; n++ {
+			// each run is dispatched up to a second late
+			job.claim(job.nextRun.Add(time.Duration(n%10) * 100 * time.Millisecond))
+		}
+		got := job.nextRun
+		job.mu.Unlock()
+		want := first.Add(100 * time.Hour)
+		if job == daily {
+			want = first.AddDate(0, 0, 100)
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s: run 100 at %v, want %v", job, got, want)
+		}
+	}
+
+	// runs were missed, so the next one counts from the late run
+	job := scheduler.EveryDuration(time.Hour)
+	job.Do(task)
+	late := job.nextRun.Add(3*time.Hour + time.Minute)
+	job.mu.Lock()
+	job.claim(late)
+	got := job.nextRun
+	job.mu.Unlock()
+	if want := late.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("after missed runs the next one is at %v, want %v", got, want)
+	}
+}
+
+func TestScheduler_RunEvery(t *testing.T) {
+	scheduler := NewScheduler()
+	job, err := scheduler.RunEvery(90*time.Second, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.interval != uint64(90*time.Second) || job.unit != unitDuration {
+		t.Errorf("got every %d %s, want every 90 seconds", job.interval, job.unit)
+	}
+	fine, err := scheduler.RunEvery(1500*time.Millisecond, task)
+	if err != nil || fine.interval != uint64(1500*time.Millisecond) {
+		t.Errorf("RunEvery(1.5s) = %v, %v, want a job every 1.5s", fine, err)
+	}
+	scheduler.RemoveByReference(fine)
+
+	for _, d := range []time.Duration{0, -time.Second} {
+		if _, err := scheduler.RunEvery(d, task); err == nil {
+			t.Errorf("RunEvery(%s) should fail", d)
+		}
+	}
+	if _, err := scheduler.RunEvery(time.Second, nil); err == nil {
+		t.Error("RunEvery with a nil function should fail")
+	}
+	if len(scheduler.jobs) != 1 {
+		t.Errorf("failed helpers must not add jobs, have %d", len(scheduler.jobs))
+	}
+
+	scheduler.SetDuplicatePolicy(DuplicateReject)
+	if job, err := scheduler.RunEvery(90*time.Second, task); err == nil || job != nil {
+		t.Errorf("RunEvery() = %v, %v, want the duplicate's error", job, err)
+	}
+	if _, err := scheduler.RunDailyAt("10:30", task); err != nil {
+		t.Error(err)
+	}
+	if _, err := scheduler.RunDailyAt("10:30", task); err == nil {
+		t.Error("RunDailyAt of a duplicate should fail")
+	}
+}
+
+func TestScheduler_RunDailyAt(t *testing.T) {
+	scheduler := NewScheduler()
+	job, err := scheduler.RunDailyAt("10:30", task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := job.NextScheduledTime()
+	if next.Hour() != 10 || next.Minute() != 30 || job.unit != UnitDays {
+		t.Errorf("got %s every %s, want 10:30 daily", next, job.unit)
+	}
+
+	if _, err := scheduler.RunDailyAt("25:61", task); err == nil {
+		t.Error("RunDailyAt with an invalid time should fail")
+	}
+	if len(scheduler.jobs) != 1 {
+		t.Errorf("failed helpers must not add jobs, have %d", len(scheduler.jobs))
+	}
+}
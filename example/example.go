@@ -40,7 +40,7 @@ func main() {
 	_, time := gocron.NextRun()
 	fmt.Println(time)
 
-	// gocron.Remove(task)
+	// gocron.RemoveAllByFunction(task)
 	// gocron.Clear()
 
 	// function Start start all the pending jobs
@@ -0,0 +1,487 @@
+package gocron_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jasonlvhit/gocron"
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+// A scheduler reading a fake clock that stands at noon UTC on Wednesday,
+// 1 May 2024, so the examples do not depend on when they run
+func exampleScheduler() (*gocron.Scheduler, *clock.Fake) {
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	s := gocron.NewScheduler()
+	s.ChangeLoc(time.UTC)
+	s.SetClock(fake)
+	return s, fake
+}
+
+func ExampleScheduler_Every() {
+	s, _ := exampleScheduler()
+	s.Every(1).Day().At("10:30").Do(func() {})
+
+	job, _ := s.NextRun()
+	fmt.Println(job.NextScheduledTime().Format(time.RFC3339))
+	// Output: 2024-05-02T10:30:00Z
+}
+
+func ExampleScheduler_SetClock() {
+	s, fake := exampleScheduler()
+	job := s.Every(1).Hour().Name("sync")
+	job.Do(func() { fmt.Println("sync at", fake.Now().Format("15:04")) })
+
+	for i := 0; i < 3; i++ {
+		fake.Advance(time.Hour + time.Second)
+		s.RunPendingAndWait()
+	}
+	fmt.Println(job)
+	// Output:
+	// sync at 13:00
+	// sync at 14:00
+	// sync at 15:00
+	// [sync] every hour, next 2024-05-01T16:00:00Z
+}
+
+func ExampleJob_At() {
+	s, _ := exampleScheduler()
+	job := s.Every(1).Monday().At("18:30")
+	job.Do(func() {})
+
+	next := job.NextScheduledTime()
+	fmt.Println(next.Weekday(), next.Format(time.RFC3339))
+	// Output: Monday 2024-05-06T18:30:00Z
+}
+
+func ExampleJob_OrderingKey() {
+	s, _ := exampleScheduler()
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	s.Every(1).Day().OrderingKey("acme").Do(func() {
+		fmt.Println("apply config")
+		wg.Done()
+	})
+	s.Every(1).Day().OrderingKey("acme").Do(func() {
+		fmt.Println("report")
+		wg.Done()
+	})
+
+	s.RunAll()
+	wg.Wait()
+	// Output:
+	// apply config
+	// report
+}
+
+func ExampleJob_SingletonMode() {
+	s, _ := exampleScheduler()
+	release := make(chan struct{})
+	job := s.Every(1).Second().SingletonMode()
+	job.Do(func() { <-release })
+
+	s.RunAll()
+	s.RunAll() // the first run is still going
+	close(release)
+	s.Wait(time.Second)
+	fmt.Println(job.SkippedRuns())
+	// Output: 1
+}
+
+func ExampleScheduler_RunDailyAt() {
+	s, _ := exampleScheduler()
+	job, err := s.RunDailyAt("07:15", func() {})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(job.NextScheduledTime().Format(time.RFC3339))
+
+	_, err = s.RunDailyAt("7.15", func() {})
+	fmt.Println(err)
+	// Output:
+	// 2024-05-02T07:15:00Z
+	// time format error
+}
+
+func ExampleScheduler_Cron() {
+	s, fake := exampleScheduler()
+	// Friday afternoon, the next weekday run is on Monday
+	fake.Set(time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC))
+	job := s.Cron("30 9 * * MON-FRI")
+	job.Do(func() {})
+
+	next := job.NextScheduledTime()
+	fmt.Println(next.Weekday(), next.Format(time.RFC3339))
+
+	fmt.Println(s.Cron("30 9 * * MON-FRY").Do(func() {}))
+	// Output:
+	// Monday 2024-05-06T09:30:00Z
+	// invalid cron expression "30 9 * * MON-FRY": unknown day of week "FRY"
+}
+
+func ExampleScheduler_RunCron() {
+	s, fake := exampleScheduler()
+	job, err := s.RunCron("0 9 * * MON-FRI", func() { fmt.Println("standup at", fake.Now().Format("Mon 15:04")) })
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(job.NextScheduledTime().Format(time.RFC3339))
+
+	s.FastForward(context.Background(), time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC))
+	_, err = s.RunCron("0 9 * *", func() {})
+	fmt.Println(err)
+	fmt.Println(s.Len(), "job")
+	// Output:
+	// 2024-05-02T09:00:00Z
+	// standup at Thu 09:00
+	// standup at Fri 09:00
+	// invalid cron expression "0 9 * *": want 5 fields, got 4
+	// 1 job
+}
+
+func ExampleScheduler_RunEvery() {
+	s, fake := exampleScheduler()
+	job, _ := s.RunEvery(1500*time.Millisecond, func() { fmt.Println("tick at", fake.Now().Format("15:04:05.0")) })
+
+	for i := 0; i < 2; i++ {
+		fake.Advance(job.NextScheduledTime().Sub(fake.Now()) + time.Millisecond)
+		s.RunPendingAndWait()
+	}
+	if _, err := s.RunEvery(0, func() {}); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// tick at 12:00:01.5
+	// tick at 12:00:03.0
+	// interval must be positive, got 0s
+}
+
+func ExampleJob_Tag() {
+	s, _ := exampleScheduler()
+	for _, tenant := range []string{"acme", "globex"} {
+		s.Every(1).Hour().Tag("sync", tenant).Do(func(tenant string) {}, tenant)
+	}
+
+	s.RemoveByTag("acme")
+	for _, job := range s.FindJobsByTag("sync") {
+		fmt.Println(job.Tags())
+	}
+	// Output: [sync globex]
+}
+
+func ExampleScheduler_StartWithContext() {
+	s, fake := exampleScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	started, done := make(chan struct{}), make(chan struct{})
+	s.Every(1).Hour().Do(func(ctx context.Context) {
+		fmt.Println("job started at", fake.Now().Format("15:04"))
+		close(started)
+		<-ctx.Done()
+		fmt.Println("job saw", ctx.Err())
+		close(done)
+	})
+
+	s.StartWithContext(ctx)
+	// the loop waits on the fake clock for the job to come due
+	fake.BlockUntil(1)
+	fake.Advance(time.Hour + time.Second)
+	<-started
+	cancel()
+	<-done
+	if err := s.Wait(time.Second); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// job started at 13:00
+	// job saw context canceled
+}
+
+func ExampleScheduler_SetEventListeners() {
+	s, fake := exampleScheduler()
+	s.SetEventListeners(
+		func(job *gocron.Job) { fmt.Println("starting", job.Tags()) },
+		func(job *gocron.Job, took time.Duration) { fmt.Println("finished", job.Tags(), took > 0) },
+	)
+	s.Every(1).Hour().StartImmediately().Tag("report").Do(func() {})
+
+	fake.Advance(time.Second)
+	s.RunPendingAndWait()
+	// Output:
+	// starting [report]
+	// finished [report] true
+}
+
+func ExampleJob_Pause() {
+	s, fake := exampleScheduler()
+	job := s.Every(1).Hour()
+	job.Do(func() {})
+
+	// 40 minutes before the next run, the job is paused for three hours
+	fake.Advance(20 * time.Minute)
+	job.Pause(gocron.PauseFreezeSchedule)
+	fake.Advance(3 * time.Hour)
+	s.RunPendingAndWait()
+	fmt.Println(job.IsPaused(), job.RunCount())
+
+	job.Resume()
+	fmt.Println(job.NextScheduledTime().Format("15:04"))
+	// Output:
+	// true 0
+	// 16:00
+}
+
+func ExampleScheduler_Pause() {
+	s, fake := exampleScheduler()
+	job := s.Every(1).Hour()
+	job.Do(func() {})
+
+	// a maintenance window through three runs
+	s.Pause()
+	fake.Advance(3*time.Hour + 30*time.Minute)
+	s.RunPendingAndWait()
+	s.Resume()
+	fmt.Println(job.RunCount(), job.NextScheduledTime().Format("15:04"))
+	// Output: 0 16:00
+}
+
+func ExampleJob_Retry() {
+	s, fake := exampleScheduler()
+	attempts := 0
+	called := make(chan struct{}, 3)
+	job := s.Every(1).Hour().Retry(3, time.Second)
+	job.Do(func() error {
+		attempts++
+		fmt.Println("attempt", attempts, "at", fake.Now().Format("15:04:05"))
+		called <- struct{}{}
+		if attempts < 3 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	job.RunNow()
+	// the retries wait on the fake clock, one second, then two
+	for _, backoff := range []time.Duration{time.Second, 2 * time.Second} {
+		<-called
+		fake.BlockUntil(1)
+		fake.Advance(backoff)
+	}
+	s.Wait(time.Second)
+	fmt.Println(job.Retries(), "retries")
+	// Output:
+	// attempt 1 at 12:00:00
+	// attempt 2 at 12:00:01
+	// attempt 3 at 12:00:03
+	// 2 retries
+}
+
+func ExampleJob_Timeout() {
+	s, fake := exampleScheduler()
+	s.SetLogger(nil)
+	job := s.Every(1).Hour().Timeout(time.Minute).OnError(func(err error) {
+		fmt.Println("failed:", err)
+	})
+	job.Do(func(ctx context.Context) {
+		// a poll that only returns once it is given up on
+		<-ctx.Done()
+	})
+
+	job.RunNow()
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	s.Wait(time.Second)
+	// Output: failed: run timed out
+}
+
+func ExampleJob_RunNow() {
+	s, _ := exampleScheduler()
+	job := s.Every(1).Day().At("02:00").Name("backup")
+	job.Do(func() { fmt.Println("backup") })
+
+	// a run by hand keeps the job's schedule by default
+	job.RunNow()
+	s.Wait(time.Second)
+	fmt.Println(job)
+	// Output:
+	// backup
+	// [backup] every day at 02:00, next 2024-05-02T02:00:00Z
+}
+
+func ExampleScheduler_RunByTag() {
+	s, _ := exampleScheduler()
+	for _, tenant := range []string{"acme", "globex"} {
+		s.Every(1).Day().Tag("reports", tenant).Do(func(tenant string) {}, tenant)
+	}
+
+	s.RunByTag("reports")
+	s.Wait(time.Second)
+	for _, job := range s.Jobs() {
+		fmt.Println(job.Tags(), job.RunCount())
+	}
+	fmt.Println(s.RunByTag("invoices"))
+	// Output:
+	// [reports acme] 1
+	// [reports globex] 1
+	// no job tagged invoices
+}
+
+func ExampleJob_LimitRunsTo() {
+	s, fake := exampleScheduler()
+	s.Every(1).Hour().LimitRunsTo(2).Do(func() { fmt.Println("run at", fake.Now().Format("15:04")) })
+
+	s.FastForward(context.Background(), fake.Now().Add(5*time.Hour))
+	fmt.Println(s.Len(), "jobs left")
+	// Output:
+	// run at 13:00
+	// run at 14:00
+	// 0 jobs left
+}
+
+func ExampleScheduler_FastForward() {
+	s, fake := exampleScheduler()
+	s.Every(90).Minutes().Name("sync").Do(func() {})
+	s.Every(1).Day().At("15:00").Name("report").Do(func() {})
+
+	report, err := s.FastForward(context.Background(), fake.Now().Add(4*time.Hour))
+	if err != nil {
+		fmt.Println(err)
+	}
+	for _, run := range report.Runs {
+		fmt.Println(run.At.Format("15:04"), run.Job.GetName())
+	}
+	// Output:
+	// 13:30 sync
+	// 15:00 sync
+	// 15:00 report
+}
+
+func ExampleScheduler_Export() {
+	s, fake := exampleScheduler()
+	s.Every(1).Hour().Tag("sync").Do(func() {})
+	s.FastForward(context.Background(), fake.Now().Add(150*time.Minute))
+	states := s.Export()
+	fmt.Println(states[0].RunCount, states[0].NextRun.Format("15:04"))
+
+	// a new process registers the job again and carries its timing over
+	restarted, _ := exampleScheduler()
+	job := restarted.Every(1).Hour().Tag("sync")
+	job.Do(func() {})
+	fmt.Println(restarted.Load(states), job.RunCount(), job.NextScheduledTime().Format("15:04"))
+	// Output:
+	// 2 15:00
+	// 1 2 15:00
+}
+
+func ExampleSecret() {
+	s, _ := exampleScheduler()
+	login := func(user, password string) {
+		fmt.Println("login", user, "with a", len(password), "character password")
+	}
+	job := s.Every(1).Hour().RedactParams(0)
+	job.Do(login, "alice", gocron.Secret("hunter2"))
+
+	fmt.Println(job.Params())
+	job.RunNow()
+	s.Wait(time.Second)
+	// Output:
+	// [[REDACTED] [REDACTED]]
+	// login alice with a 7 character password
+}
+
+func ExampleScheduler_SetMonitor() {
+	s, fake := exampleScheduler()
+	monitor := gocron.NewMemoryMonitor()
+	s.SetMonitor(monitor)
+	s.Every(1).Hour().Name("sync").Do(func() {})
+	s.Every(2).Hours().Name("export").Do(func() error { return errors.New("disk full") })
+
+	s.FastForward(context.Background(), fake.Now().Add(4*time.Hour))
+	stats := monitor.Stats()
+	fmt.Println("sync", stats["sync"].Success, "ok", stats["sync"].Fail, "failed")
+	fmt.Println("export", stats["export"].Success, "ok", stats["export"].Fail, "failed")
+	// Output:
+	// sync 4 ok 0 failed
+	// export 0 ok 2 failed
+}
+
+func ExampleJob_DayOfTheMonth() {
+	s, _ := exampleScheduler()
+	job := s.Every(1).Month().DayOfTheMonth(31).At("02:00")
+	job.Do(func() {})
+
+	// May has a 31st, June does not
+	fmt.Println(job.NextScheduledTime().Format(time.RFC3339))
+	// Output: 2024-05-31T02:00:00Z
+}
+
+func ExampleJob_FirstWeekdayOfTheMonth() {
+	s, _ := exampleScheduler()
+	job := s.Every(1).FirstWeekdayOfTheMonth(time.Monday).At("09:00")
+	job.Do(func() {})
+
+	next := job.NextScheduledTime()
+	fmt.Println(next.Weekday(), next.Format(time.RFC3339))
+	// Output: Monday 2024-05-06T09:00:00Z
+}
+
+func ExampleJob_StartAt() {
+	s, fake := exampleScheduler()
+	job := s.Every(1).Hour().StartAt(fake.Now().Add(15 * time.Minute))
+	job.Do(func() {})
+
+	fmt.Println(job.NextScheduledTime().Format("15:04"))
+	// Output: 12:15
+}
+
+func ExampleRunNumber() {
+	s, fake := exampleScheduler()
+	s.Every(1).Hour().Do(func(ctx context.Context) {
+		n, _ := gocron.RunNumber(ctx)
+		fmt.Printf("export-%06d.parquet\n", n)
+	})
+
+	s.FastForward(context.Background(), fake.Now().Add(3*time.Hour))
+	// Output:
+	// export-000001.parquet
+	// export-000002.parquet
+	// export-000003.parquet
+}
+
+func ExampleRunAttempt() {
+	s, fake := exampleScheduler()
+	s.Every(1).Hour().Retry(2, time.Minute).Do(func(ctx context.Context) error {
+		n, _ := gocron.RunNumber(ctx)
+		attempt, _ := gocron.RunAttempt(ctx)
+		fmt.Printf("export-%06d.%d.parquet\n", n, attempt)
+		if attempt < 2 {
+			return errors.New("upload failed")
+		}
+		return nil
+	})
+
+	s.FastForward(context.Background(), fake.Now().Add(2*time.Hour))
+	// Output:
+	// export-000001.1.parquet
+	// export-000001.2.parquet
+	// export-000002.1.parquet
+	// export-000002.2.parquet
+}
+
+func ExampleSetDefaultScheduler() {
+	s, _ := exampleScheduler()
+	previous := gocron.DefaultScheduler()
+	gocron.SetDefaultScheduler(s)
+	defer gocron.SetDefaultScheduler(previous)
+
+	// a library using the package-level functions schedules on s
+	gocron.Every(1).Day().At("06:00").Do(func() {})
+	_, next := gocron.NextRun()
+	fmt.Println(s.Len(), next.Format(time.RFC3339))
+	// Output: 1 2024-05-02T06:00:00Z
+}
@@ -0,0 +1,276 @@
+package gocron
+
+import (
+	"context"
+	"reflect"
+	"runtime/debug"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+// jobTask is a finalized job function with its params, all the executor
+// needs to call it
+type jobTask struct {
+	// the function's name, runs in flight are counted by it
+	name   string
+	fn     reflect.Value
+	params []interface{}
+	// deep copy params for every call, see CopyParamsPerRun
+	copyParams bool
+	// called on the call's goroutine right before the call, may be nil
+	started func()
+	// when the run was scheduled, its number and which call of it this is,
+	// see IdempotencyKey, RunNumber and RunAttempt
+	due     time.Time
+	run     int
+	attempt int
+	// calls after one returning an error, the first after backoff, waited
+	// on clock; retrying is called before each of them, see Retry
+	retries  int
+	backoff  time.Duration
+	clock    clock.Clock
+	retrying func()
+	// how long the run may take on clock, and what is called with the
+	// result of a call that went on after it, see Timeout
+	timeout time.Duration
+	late    func(runResult)
+	// called instead of the function in shadow mode when not nil, see
+	// SetShadowMode
+	shadow func()
+}
+
+// runResult is what a call returned, when it began and how long it took.
+// A call that panicked has the recovered value and the stack instead of
+// results, and a run dropped from its lane before it started has dropped set.
+// attempts counts the calls of the run, more than one when it was retried,
+// and a run given up on after its timeout has timedOut set. A shadow run
+// that never called the function has shadow set.
+type runResult struct {
+	out       []reflect.Value
+	began     time.Time
+	took      time.Duration
+	recovered interface{}
+	stack     []byte
+	dropped   bool
+	attempts  int
+	timedOut  bool
+	shadow    bool
+}
+
+// executor calls tasks, each on its own goroutine or queued on a lane,
+// and counts them until they return. It knows nothing about schedules.
+type executor struct {
+	inflight *inflight
+	// the context handed to functions taking one first
+	ctx func() context.Context
+	// limits the calls going at once when not nil, see SetMaxConcurrentJobs
+	slots     chan struct{}
+	limitMode LimitMode
+}
+
+// The executor for the scheduler's jobs, s may be nil for jobs made with NewJob
+func (s *Scheduler) executor() executor {
+	e := executor{inflight: s.tracker(), ctx: s.context}
+	if s != nil {
+		s.mu.RLock()
+		e.slots, e.limitMode = s.slots, s.limitMode
+		s.mu.RUnlock()
+	}
+	return e
+}
+
+// Build the arguments of one call of t
+func (e executor) args(t jobTask) ([]reflect.Value, error) {
+	typ := t.fn.Type()
+	params := t.params
+	if err := validateParams(typ, params); err != nil {
+		return nil, err
+	}
+	if t.copyParams {
+		var err error
+		if params, err = copyParams(params); err != nil {
+			return nil, err
+		}
+	}
+	in := make([]reflect.Value, 0, typ.NumIn())
+	skip := 0
+	if injectsContext(typ, params) {
+		in = append(in, reflect.ValueOf(runContext(e.ctx(), t)))
+		skip = 1
+	}
+	for i, param := range params {
+		in = append(in, paramValue(typ, param, i, skip))
+	}
+	return in, nil
+}
+
+// Start a call of t, on lane l when it is not nil. done is called with
+// the result on the call's goroutine before the call counts as returned,
+// also for a run the lane drops. An error means t could not be called
+// and done is never called.
+func (e executor) execute(t jobTask, l *lane, done func(runResult)) error {
+	t.attempt = 1
+	cancel := context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		// the run's own context, cancelled when it times out
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(e.ctx())
+		e.ctx = func() context.Context { return ctx }
+	}
+	in, err := e.args(t)
+	if err != nil {
+		cancel()
+		return err
+	}
+	slots := e.slots
+	if l != nil {
+		// a lane runs one call at a time whatever the global limit
+		slots = nil
+	}
+	if slots != nil && e.limitMode == LimitReschedule {
+		select {
+		case slots <- struct{}{}:
+		default:
+			cancel()
+			return errAtLimit
+		}
+	}
+	e.inflight.add(t.name)
+	// returns a channel closed once the call did, later than the run for
+	// a call given up on after its timeout
+	run := func() <-chan struct{} {
+		defer cancel()
+		defer e.inflight.done(t.name)
+		if slots != nil {
+			if e.limitMode == LimitWait {
+				slots <- struct{}{}
+			}
+			defer func() { <-slots }()
+		}
+		if t.started != nil {
+			t.started()
+		}
+		began := time.Now()
+		var r runResult
+		var returned <-chan struct{} = returnedCh
+		if t.shadow != nil {
+			t.shadow()
+			r.shadow = true
+		} else {
+			r, returned = e.callTimed(t, in, cancel)
+		}
+		r.began, r.took = began, time.Since(began)
+		done(r)
+		return returned
+	}
+	if l != nil {
+		// the lane stays taken until the call returned, even one that
+		// timed out, so that the calls of a key never overlap
+		l.push(func() { <-run() }, func() {
+			defer cancel()
+			defer e.inflight.done(t.name)
+			done(runResult{dropped: true})
+		})
+	} else {
+		go run()
+	}
+	return nil
+}
+
+// returnedCh is a closed channel, for calls that returned already
+var returnedCh = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// Call t with in as callRetrying does, giving up on the call once it took
+// t.timeout. The call then goes on on its own goroutine with its context
+// cancelled, and its result goes to t.late. The channel returned is
+// closed once the call returned.
+func (e executor) callTimed(t jobTask, in []reflect.Value, cancel context.CancelFunc) (runResult, <-chan struct{}) {
+	if t.timeout <= 0 {
+		return e.callRetrying(t, in), returnedCh
+	}
+	res := make(chan runResult, 1)
+	ticker := t.clock.NewTicker(t.timeout)
+	defer ticker.Stop()
+	defer e.inflight.await(t.clock.Now().Add(t.timeout), false)()
+	go func() { res <- e.callRetrying(t, in) }()
+	select {
+	case r := <-res:
+		return r, returnedCh
+	case <-ticker.C():
+		cancel()
+		returned := make(chan struct{})
+		go func() {
+			defer close(returned)
+			if r := <-res; t.late != nil {
+				t.late(r)
+			}
+		}()
+		return runResult{timedOut: true}, returned
+	}
+}
+
+// Call t with in, and again as long as it returns an error or panics and
+// has retries left, doubling the wait before each retry
+func (e executor) callRetrying(t jobTask, in []reflect.Value) runResult {
+	r := call(t.fn, in)
+	attempts := 1
+	backoff := t.backoff
+	for attempts <= t.retries && (r.recovered != nil || resultError(r.out) != nil) {
+		if !e.wait(t.clock, backoff) {
+			break
+		}
+		if backoff < maxRetryBackoff/2 {
+			backoff *= 2
+		} else {
+			backoff = maxRetryBackoff
+		}
+		t.attempt++
+		var err error
+		if in, err = e.args(t); err != nil {
+			break
+		}
+		if t.retrying != nil {
+			t.retrying()
+		}
+		r = call(t.fn, in)
+		attempts++
+	}
+	r.attempts = attempts
+	return r
+}
+
+// maxRetryBackoff bounds the doubling wait between retries
+const maxRetryBackoff = 24 * time.Hour
+
+// Wait d on c, false if the context handed to the functions is done first
+func (e executor) wait(c clock.Clock, d time.Duration) bool {
+	ctx := e.ctx()
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	ticker := c.NewTicker(d)
+	defer ticker.Stop()
+	defer e.inflight.await(c.Now().Add(d), true)()
+	select {
+	case <-ticker.C():
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Call fn, recovering a panic so it cannot take the process down
+func call(fn reflect.Value, in []reflect.Value) (r runResult) {
+	defer func() {
+		if r.recovered = recover(); r.recovered != nil {
+			r.stack = debug.Stack()
+		}
+	}()
+	r.out = fn.Call(in)
+	return r
+}
@@ -0,0 +1,102 @@
+package gocron
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type ctxKey struct{}
+
+func newTestExecutor() executor {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "run")
+	return executor{inflight: &inflight{}, ctx: func() context.Context { return ctx }}
+}
+
+func TestExecutor_Args(t *testing.T) {
+	e := newTestExecutor()
+
+	in, err := e.args(jobTask{fn: reflect.ValueOf(func(a int, b string) {}), params: []interface{}{1, "x"}})
+	if err != nil || len(in) != 2 || in[0].Int() != 1 || in[1].String() != "x" {
+		t.Errorf("args = %v, %v; want [1 x]", in, err)
+	}
+	if _, err := e.args(jobTask{fn: reflect.ValueOf(func(a int) {})}); err == nil {
+		t.Error("a missing param should fail")
+	}
+
+	in, err = e.args(jobTask{fn: reflect.ValueOf(func(ctx context.Context, a int) {}), params: []interface{}{1}})
+	if err != nil || len(in) != 2 {
+		t.Fatalf("args = %v, %v; want a context and 1", in, err)
+	}
+	if ctx := in[0].Interface().(context.Context); ctx.Value(ctxKey{}) != "run" {
+		t.Error("the executor's context was not injected")
+	}
+	// a context passed as a param is used as is
+	given := context.WithValue(context.Background(), ctxKey{}, "param")
+	in, _ = e.args(jobTask{fn: reflect.ValueOf(func(ctx context.Context) {}), params: []interface{}{given}})
+	if ctx := in[0].Interface().(context.Context); ctx.Value(ctxKey{}) != "param" {
+		t.Error("a context param was replaced")
+	}
+
+	shared := []int{1}
+	in, _ = e.args(jobTask{fn: reflect.ValueOf(func(s []int) {}), params: []interface{}{shared}, copyParams: true})
+	in[0].Index(0).SetInt(2)
+	if shared[0] != 1 {
+		t.Error("copyParams handed the shared param to the call")
+	}
+	type hidden struct{ n int }
+	if _, err := e.args(jobTask{fn: reflect.ValueOf(func(h hidden) {}), params: []interface{}{hidden{}}, copyParams: true}); err == nil {
+		t.Error("copying an uncopyable param should fail")
+	}
+}
+
+func TestExecutor_Execute(t *testing.T) {
+	e := newTestExecutor()
+	release := make(chan struct{})
+	results := make(chan runResult, 1)
+
+	fn := func(n int) int { <-release; return n * 2 }
+	if err := e.execute(jobTask{fn: reflect.ValueOf(fn), params: []interface{}{21}}, nil, func(r runResult) {
+		if e.inflight.count() != 1 {
+			t.Error("done should run before the call counts as returned")
+		}
+		results <- r
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n := e.inflight.count(); n != 1 {
+		t.Errorf("%d calls in flight, want 1", n)
+	}
+	close(release)
+	r := <-results
+	if len(r.out) != 1 || r.out[0].Int() != 42 {
+		t.Errorf("got %v, want 42", r.out)
+	}
+	select {
+	case <-e.inflight.idle():
+	case <-time.After(time.Second):
+		t.Fatal("call still counted in flight after it returned")
+	}
+
+	called := false
+	if err := e.execute(jobTask{fn: reflect.ValueOf(fn)}, nil, func(runResult) { called = true }); err == nil {
+		t.Error("a call with missing params should fail")
+	}
+	if called || e.inflight.count() != 0 {
+		t.Error("a call that failed to start was counted or finished")
+	}
+}
+
+func TestExecutor_ExecuteOnLane(t *testing.T) {
+	e := newTestExecutor()
+	l := newLane(DefaultLaneCapacity, LaneBlock)
+	order := make(chan int, 2)
+	for i := 1; i <= 2; i++ {
+		e.execute(jobTask{fn: reflect.ValueOf(func(n int) { order <- n }), params: []interface{}{i}}, l, func(runResult) {})
+	}
+	<-e.inflight.idle()
+	if a, b := <-order, <-order; a != 1 || b != 2 {
+		t.Errorf("lane ran %d then %d, want 1 then 2", a, b)
+	}
+}
@@ -0,0 +1,152 @@
+package gocron
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"sync"
+	"time"
+)
+
+// serializes the check and publish in PublishExpvar
+var expvarMu sync.Mutex
+
+// DefaultExpvarJobLimit is how many jobs the expvar rendering lists before truncating
+const DefaultExpvarJobLimit = 100
+
+type expvarJob struct {
+	Func     string    `json:"func"`
+	Interval uint64    `json:"interval"`
+	Unit     string    `json:"unit"`
+	LastRun  time.Time `json:"last_run"`
+	NextRun  time.Time `json:"next_run"`
+	Version  uint64    `json:"version"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+	Tags   []string          `json:"tags,omitempty"`
+
+	// seconds until NextRun, negative when overdue, left out when unscheduled
+	UntilNextRun *float64    `json:"until_next_run_seconds,omitempty"`
+	SLOViolated  []SLOClause `json:"slo_violated,omitempty"`
+	// left out before the first run returns
+	Duration *expvarDuration `json:"duration,omitempty"`
+}
+
+// A DurationSummary in seconds
+type expvarDuration struct {
+	Count uint64  `json:"count"`
+	Sum   float64 `json:"sum_seconds"`
+	Max   float64 `json:"max_seconds"`
+	P50   float64 `json:"p50_seconds"`
+	P95   float64 `json:"p95_seconds"`
+	P99   float64 `json:"p99_seconds"`
+}
+
+func newExpvarDuration(s DurationSummary) *expvarDuration {
+	if s.Count == 0 {
+		return nil
+	}
+	return &expvarDuration{
+		Count: s.Count,
+		Sum:   s.Sum.Seconds(),
+		Max:   s.Max.Seconds(),
+		P50:   s.P50.Seconds(),
+		P95:   s.P95.Seconds(),
+		P99:   s.P99.Seconds(),
+	}
+}
+
+type expvarStatus struct {
+	Jobs  []expvarJob `json:"jobs"`
+	Total int         `json:"total"`
+	More  int         `json:"more,omitempty"`
+	// jobs with a violated SLO clause, counting those past the limit
+	SLOViolations int `json:"slo_violations"`
+}
+
+// schedulerVar renders a scheduler for expvar
+type schedulerVar struct {
+	s *Scheduler
+}
+
+// String implements expvar.Var
+func (v schedulerVar) String() string {
+	s := v.s
+	s.mu.RLock()
+	limit := s.expvarLimit
+	status := expvarStatus{}
+	now := s.clock.Now()
+	for _, job := range s.jobs {
+		job.mu.Lock()
+		// jobs without a successful Do are not scheduled, leave them out
+		if job.jobFunc == "" {
+			job.mu.Unlock()
+			continue
+		}
+		status.Total++
+		var violated []SLOClause
+		if job.slo != nil {
+			violated = job.slo.violations()
+		}
+		if len(violated) > 0 {
+			status.SLOViolations++
+		}
+		if len(status.Jobs) >= limit {
+			status.More++
+			job.mu.Unlock()
+			continue
+		}
+		var until *float64
+		if d, ok := job.untilNextRun(now); ok {
+			secs := d.Seconds()
+			until = &secs
+		}
+		status.Jobs = append(status.Jobs, expvarJob{
+			Func:     job.jobFunc,
+			Interval: job.interval,
+			Unit:     job.unit,
+			LastRun:  job.lastRun,
+			NextRun:  job.nextRun,
+			Version:  job.version,
+			Labels:   job.labelsCopy(),
+			Tags:     append([]string(nil), job.tags...),
+
+			UntilNextRun: until,
+			SLOViolated:  violated,
+			Duration:     newExpvarDuration(job.durations.summary()),
+		})
+		job.mu.Unlock()
+	}
+	s.mu.RUnlock()
+	b, err := json.Marshal(status)
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
+
+// PublishExpvar - Publish the scheduler's jobs under name in expvar, so
+// they show up on /debug/vars. An error is returned if name is taken.
+//
+// expvar has no way to unpublish a variable, so the name stays taken for
+// the life of the process; publish each scheduler once.
+func (s *Scheduler) PublishExpvar(name string) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvar.Get(name) != nil {
+		return errors.New("expvar " + name + " is already published")
+	}
+	expvar.Publish(name, schedulerVar{s})
+	return nil
+}
+
+// SetExpvarJobLimit - Set how many jobs PublishExpvar renders, the rest
+// are counted in a "more" field
+func (s *Scheduler) SetExpvarJobLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	s.mu.Lock()
+	s.expvarLimit = n
+	s.mu.Unlock()
+}
@@ -0,0 +1,49 @@
+package gocron
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestScheduler_PublishExpvar(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.Every(1).Minute().Label("team", "ops").Do(task)
+	scheduler.Every(2).Hours().Do(task)
+	scheduler.Every(1).Day().Do(task)
+	scheduler.SetExpvarJobLimit(2)
+
+	if err := scheduler.PublishExpvar("gocron_test_scheduler"); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewScheduler().PublishExpvar("gocron_test_scheduler"); err == nil {
+		t.Error("publishing a taken name should return an error")
+	}
+
+	rec := httptest.NewRecorder()
+	expvar.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/vars", nil))
+
+	var vars struct {
+		Scheduler expvarStatus `json:"gocron_test_scheduler"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &vars); err != nil {
+		t.Fatal(err)
+	}
+	status := vars.Scheduler
+	if status.Total != 3 || len(status.Jobs) != 2 || status.More != 1 {
+		t.Errorf("got total %d, %d jobs, %d more; want 3, 2, 1", status.Total, len(status.Jobs), status.More)
+	}
+	if status.Jobs[0].Version != 1 || status.Jobs[1].Version != 0 {
+		t.Errorf("versions %d and %d, want 1 after the label and 0", status.Jobs[0].Version, status.Jobs[1].Version)
+	}
+	if status.Jobs[0].Unit != UnitMinutes || status.Jobs[1].Interval != 2 {
+		t.Errorf("unexpected jobs %+v", status.Jobs)
+	}
+	if !status.Jobs[0].NextRun.Equal(scheduler.jobs[0].nextRun) {
+		t.Errorf("next_run = %s, want %s", status.Jobs[0].NextRun, scheduler.jobs[0].nextRun)
+	}
+	if until := status.Jobs[0].UntilNextRun; until == nil || *until <= 0 || *until > 60 {
+		t.Errorf("until_next_run_seconds = %v, want at most a minute", until)
+	}
+}
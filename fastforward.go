@@ -0,0 +1,127 @@
+package gocron
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+// FastForwardReport - What FastForward dispatched, in order
+type FastForwardReport struct {
+	Runs    []FastForwardRun
+	Skipped []SkippedRun
+}
+
+// FastForwardRun - A run FastForward dispatched, at the time the fake
+// clock read then
+type FastForwardRun struct {
+	Job *Job
+	At  time.Time
+}
+
+// FastForward - Move the scheduler's clock.Fake from one due instant to
+// the next until it reads until, running what is due on each through the
+// same path as RunPending, with policies, limits and handlers, and waiting
+// for those runs before moving on. It needs SetClock with a clock.Fake and
+// must not be used while the scheduler is started.
+//
+// Jobs must be quick or fake their work: FastForward waits for every run
+// on the machine's clock, and a job sleeping on the fake clock would never
+// wake up. The clock does move on for the waits of the scheduler itself:
+// to the end of a Retry backoff once every run in flight waits for one,
+// and to a run's Timeout once it has been calling its function for a
+// moment. ctx bounds the whole call, FastForward returns what it ran so
+// far with ctx.Err() once it is done.
+func (s *Scheduler) FastForward(ctx context.Context, until time.Time) (FastForwardReport, error) {
+	var report FastForwardReport
+	s.mu.RLock()
+	fake, ok := s.clock.(*clock.Fake)
+	running := false
+	if s.loopDone != nil {
+		select {
+		case <-s.loopDone:
+		default:
+			running = true
+		}
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return report, errors.New("FastForward() needs a clock.Fake, see SetClock")
+	}
+	if running {
+		return report, errors.New("FastForward() cannot be used while the scheduler is started")
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		next, ok := s.nextDue()
+		if !ok || next.After(until) {
+			fake.Set(until)
+			return report, nil
+		}
+		// RunPending runs jobs strictly after they are due
+		fake.Set(next.Add(time.Nanosecond))
+		pass := s.runPendingAt(fake.Now())
+		for _, job := range pass.Dispatched {
+			report.Runs = append(report.Runs, FastForwardRun{Job: job, At: pass.Start})
+		}
+		report.Skipped = append(report.Skipped, pass.Skipped...)
+		if err := s.fastForwardRuns(ctx, fake); err != nil {
+			return report, err
+		}
+		s.checkSLOs(fake.Now())
+	}
+}
+
+// fastForwardGrace is how long runs still calling their function may take
+// on the machine's clock before FastForward moves the fake clock on to
+// their timeout
+const fastForwardGrace = 50 * time.Millisecond
+
+// Wait for the runs in flight to return, moving fake on to what they wait
+// for on it: at once when every run is waiting out a retry backoff, after
+// fastForwardGrace when a run is still calling and has a timeout
+func (s *Scheduler) fastForwardRuns(ctx context.Context, fake *clock.Fake) error {
+	idle := s.inflight.idle()
+	grace := time.Now().Add(fastForwardGrace)
+	for {
+		select {
+		case <-idle:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+		at, stalled, ok := s.inflight.nextDeadline()
+		if ok && (stalled || time.Now().After(grace)) {
+			fake.Set(at)
+			grace = time.Now().Add(fastForwardGrace)
+		}
+	}
+}
+
+// The earliest time a RunPending pass would dispatch a job, false if the
+// scheduler is paused or no job is scheduled
+func (s *Scheduler) nextDue() (time.Time, bool) {
+	s.mu.RLock()
+	lookahead, paused := s.lookahead, s.paused
+	s.mu.RUnlock()
+	if paused {
+		return time.Time{}, false
+	}
+	var next time.Time
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		if job.jobFunc != "" && !job.paused && !job.removed {
+			if due := job.nextRun.Add(-lookahead); next.IsZero() || due.Before(next) {
+				next = due
+			}
+		}
+		job.mu.Unlock()
+	}
+	return next, !next.IsZero()
+}
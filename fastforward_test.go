@@ -0,0 +1,169 @@
+package gocron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestScheduler_FastForward(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.ChangeLoc(time.UTC)
+	// a Wednesday
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	scheduler.SetClock(fake)
+	daily := scheduler.Every(1).Day().At("02:00")
+	daily.Do(task)
+	biweekly := scheduler.Every(2).Monday().At("09:00")
+	biweekly.Do(task)
+
+	until := start.AddDate(0, 0, 7)
+	report, err := scheduler.FastForward(context.Background(), until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fake.Now().Equal(until) {
+		t.Errorf("clock at %v afterwards, want %v", fake.Now(), until)
+	}
+	var dailies, biweeklies int
+	for i, run := range report.Runs {
+		if i > 0 && run.At.Before(report.Runs[i-1].At) {
+			t.Errorf("run %d at %v, before the one at %v", i, run.At, report.Runs[i-1].At)
+		}
+		switch run.Job {
+		case daily:
+			dailies++
+			if run.At.Hour() != 2 || run.At.Minute() != 0 {
+				t.Errorf("daily run at %v, want 02:00", run.At)
+			}
+		case biweekly:
+			biweeklies++
+			if run.At.Weekday() != time.Monday || run.At.Hour() != 9 {
+				t.Errorf("biweekly run at %v, want Monday 09:00", run.At)
+			}
+		}
+	}
+	if dailies != 7 || biweeklies > 1 {
+		t.Errorf("%d daily and %d biweekly runs in a week, want 7 and at most 1", dailies, biweeklies)
+	}
+	if daily.RunCount() != 7 {
+		t.Errorf("RunCount() = %d, want the runs to have executed", daily.RunCount())
+	}
+}
+
+func TestScheduler_FastForwardPolicies(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	job := scheduler.Every(1).Hour()
+	job.Do(task)
+	job.Pause(PauseRecomputeOnResume)
+
+	report, err := scheduler.FastForward(context.Background(), fake.Now().Add(5*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Runs) != 0 {
+		t.Errorf("%d runs of a paused job, want none", len(report.Runs))
+	}
+	job.Resume()
+	report, _ = scheduler.FastForward(context.Background(), fake.Now().Add(3*time.Hour))
+	if len(report.Runs) != 3 {
+		t.Errorf("%d runs in 3 hours after Resume, want 3", len(report.Runs))
+	}
+}
+
+func TestScheduler_FastForwardRetry(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	var mu sync.Mutex
+	var calls []string
+	job := scheduler.Every(1).Hour().Retry(2, time.Minute)
+	job.Do(func() error {
+		mu.Lock()
+		calls = append(calls, fake.Now().Format("15:04:05"))
+		mu.Unlock()
+		return errors.New("down")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	report, err := scheduler.FastForward(ctx, fake.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Runs) != 2 {
+		t.Errorf("%d runs in 2 hours, want 2", len(report.Runs))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// a minute's backoff, then two
+	want := []string{"13:00:00", "13:01:00", "13:03:00", "14:00:00", "14:01:00", "14:03:00"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls at %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d at %s, want %s", i, calls[i], want[i])
+		}
+	}
+	if job.Retries() != 4 {
+		t.Errorf("Retries() = %d, want 4", job.Retries())
+	}
+}
+
+func TestScheduler_FastForwardTimeout(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	var timeouts int
+	job := scheduler.Every(1).Hour().Timeout(time.Minute)
+	job.OnError(func(err error) {
+		if err == ErrTimeout {
+			timeouts++
+		}
+	})
+	job.Do(func(ctx context.Context) { <-ctx.Done() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	report, err := scheduler.FastForward(ctx, fake.Now().Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Runs) != 3 || timeouts != 3 {
+		t.Errorf("%d runs and %d timeouts in 3 hours, want 3 each", len(report.Runs), timeouts)
+	}
+	// the last run came due at 15:00 and gave up a minute later
+	if want := time.Date(2024, 5, 1, 15, 1, 0, 0, time.UTC); !fake.Now().Truncate(time.Second).Equal(want) {
+		t.Errorf("clock at %v afterwards, want %v", fake.Now(), want)
+	}
+}
+
+func TestScheduler_FastForwardErrors(t *testing.T) {
+	scheduler := NewScheduler()
+	if _, err := scheduler.FastForward(context.Background(), time.Now()); err == nil {
+		t.Error("FastForward() on the machine's clock should fail")
+	}
+
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	scheduler.Every(1).Minute().Do(task)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := scheduler.FastForward(ctx, fake.Now().Add(time.Hour)); err != context.Canceled {
+		t.Errorf("FastForward() = %v with a canceled context, want context.Canceled", err)
+	}
+
+	scheduler.Start()
+	defer scheduler.Stop()
+	if _, err := scheduler.FastForward(context.Background(), fake.Now().Add(time.Hour)); err == nil {
+		t.Error("FastForward() on a started scheduler should fail")
+	}
+}
@@ -0,0 +1,43 @@
+package gocron
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// ErrParamsNotFingerprintable - returned by FingerprintParams for params
+// without a JSON encoding, such as channels, functions or cyclic values
+var ErrParamsNotFingerprintable = errors.New("params cannot be fingerprinted")
+
+// FingerprintParams - A stable hash of params: the SHA-256 of their JSON
+// encoding, with map keys sorted, as 32 hex digits. It is the same across
+// runs and processes for equal params and differs with their order. Only
+// what JSON encodes counts, e.g. unexported struct fields do not. A
+// Secret hashes as its value.
+func FingerprintParams(params ...interface{}) (string, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+	params, _ = revealSecrets(params)
+	b, err := json.Marshal(params)
+	if err != nil {
+		return "", ErrParamsNotFingerprintable
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+type idempotencyKeyCtx struct{}
+
+// IdempotencyKey - The key of the run ctx was handed to, for calls to APIs
+// that take one: the job's function name, the time the run was scheduled
+// at and the fingerprint of its params, e.g.
+// "main.syncUsers@2024-02-01T02:00:00Z/9e6c...". ok is false outside of
+// a run and for params that cannot be fingerprinted.
+func IdempotencyKey(ctx context.Context) (key string, ok bool) {
+	key, ok = ctx.Value(idempotencyKeyCtx{}).(string)
+	return
+}
@@ -0,0 +1,63 @@
+package gocron
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFingerprintParams(t *testing.T) {
+	// golden values, fingerprints must not change between releases
+	golden := []struct {
+		params []interface{}
+		want   string
+	}{
+		{nil, "4f53cda18c2baa0c0354bb5f9a3ecbe5"},
+		{[]interface{}{1, "a"}, "2010945388e2de98f5651051478912aa"},
+		{[]interface{}{"a", 1}, "135f17a475a61afdeeaf3759ad2e45ad"},
+		{[]interface{}{map[string]int{"b": 2, "a": 1}}, "44c7deead2ed8313d29655e45c0d1469"},
+	}
+	for _, g := range golden {
+		got, err := FingerprintParams(g.params...)
+		if err != nil || got != g.want {
+			t.Errorf("FingerprintParams(%v) = %s, %v, want %s", g.params, got, err, g.want)
+		}
+	}
+
+	n := 1
+	a, _ := FingerprintParams(&n)
+	n = 2
+	if b, _ := FingerprintParams(&n); a == b {
+		t.Error("the fingerprint did not change with the value behind a pointer")
+	}
+	if _, err := FingerprintParams(make(chan int)); err != ErrParamsNotFingerprintable {
+		t.Errorf("FingerprintParams(chan) error = %v, want ErrParamsNotFingerprintable", err)
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	scheduler := NewScheduler()
+	keys := make(chan string, 2)
+	job := scheduler.Every(1).Hour()
+	job.Do(func(ctx context.Context, n int) {
+		key, _ := IdempotencyKey(ctx)
+		keys <- key
+	}, 7)
+	due := job.NextScheduledTime()
+
+	scheduler.RunAll()
+	key := <-keys
+	sum, _ := FingerprintParams(7)
+	want := job.funcName() + "@" + due.UTC().Format(time.RFC3339Nano) + "/" + sum
+	if key != want {
+		t.Errorf("IdempotencyKey() = %q, want %q", key, want)
+	}
+	scheduler.RunAll()
+	if next := <-keys; next == key || !strings.HasSuffix(next, sum) {
+		t.Errorf("the next run got key %q, want another time with the same params", next)
+	}
+	if _, ok := IdempotencyKey(context.Background()); ok {
+		t.Error("IdempotencyKey outside of a run should not be ok")
+	}
+}
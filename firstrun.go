@@ -0,0 +1,73 @@
+package gocron
+
+import (
+	"errors"
+	"time"
+)
+
+// StartImmediately - Run the job on the scheduler's next pass, then at its
+// interval from that run, e.g. s.Every(1).Hour().StartImmediately().Do(task)
+func (j *Job) StartImmediately() *Job {
+	if !j.building("StartImmediately") {
+		return j
+	}
+	j.startNow = true
+	return j
+}
+
+// StartAt - Run the job first at t, then at its interval from there, e.g.
+// s.Every(1).Hour().StartAt(midnight).Do(task). A t in the past makes the
+// job run once on the scheduler's next pass, like StartImmediately, except
+// for weekly jobs: those take t as their anchor and run first at the next
+// slot interval weeks apart from it, so every 2 weeks on Monday keeps to
+// the same Mondays across restarts. Neither can be combined with At,
+// which already fixes the first run.
+func (j *Job) StartAt(t time.Time) *Job {
+	if !j.building("StartAt") {
+		return j
+	}
+	j.startAt = t
+	return j
+}
+
+// Check the first run controls against the rest of the schedule
+func (j *Job) checkFirstRun() error {
+	switch {
+	case j.startNow && !j.startAt.IsZero():
+		return errors.New("StartImmediately() and StartAt() cannot both be used")
+	case (j.startNow || !j.startAt.IsZero()) && j.atTime != "":
+		return errors.New("StartImmediately() and StartAt() cannot be combined with At()")
+	}
+	return nil
+}
+
+// Override the first scheduled run, requires j.mu held
+func (j *Job) applyFirstRun() {
+	switch {
+	case j.startNow:
+		j.nextRun = j.now()
+	case !j.startAt.IsZero() && j.unit == UnitWeeks:
+		j.nextRun = j.weeklyFrom(j.startAt, j.now())
+	case !j.startAt.IsZero():
+		j.nextRun = j.startAt
+	default:
+		return
+	}
+	j.scheduler.wakeup()
+}
+
+// The first slot after now of a weekly job anchored at anchor, stepping by
+// calendar days so the wall-clock time survives DST changes
+func (j *Job) weeklyFrom(anchor, now time.Time) time.Time {
+	days := 7 * int(j.interval)
+	t := anchor.In(j.location())
+	if t.After(now) {
+		return t
+	}
+	n := int(now.Sub(t) / (time.Duration(days) * 24 * time.Hour))
+	t = t.AddDate(0, 0, n*days)
+	for !t.After(now) {
+		t = t.AddDate(0, 0, days)
+	}
+	return t
+}
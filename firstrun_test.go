@@ -0,0 +1,62 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJob_StartImmediately(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Hour().StartImmediately()
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	scheduler.RunPending()
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if job.RunCount() != 1 {
+		t.Errorf("RunCount() = %d, want 1", job.RunCount())
+	}
+	if until := time.Until(job.NextScheduledTime()); until < 59*time.Minute || until > time.Hour {
+		t.Errorf("next run in %v, want an hour after the first", until)
+	}
+}
+
+func TestJob_StartAt(t *testing.T) {
+	scheduler := NewScheduler()
+	start := time.Now().Add(3 * time.Hour).Truncate(time.Second)
+	job := scheduler.Every(1).Hour().StartAt(start)
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if !job.NextScheduledTime().Equal(start) {
+		t.Errorf("first run %v, want %v", job.NextScheduledTime(), start)
+	}
+	job.mu.Lock()
+	job.claim(start)
+	next := job.nextRun
+	job.mu.Unlock()
+	if want := start.Add(time.Hour); !next.Equal(want) {
+		t.Errorf("run after the first %v, want %v", next, want)
+	}
+
+	past := scheduler.Every(1).Hour().StartAt(time.Now().Add(-time.Hour))
+	past.Do(func() {})
+	if d, _ := past.TimeUntilNextRun(); d > 0 {
+		t.Error("a job started in the past should be due")
+	}
+}
+
+func TestJob_StartConflicts(t *testing.T) {
+	scheduler := NewScheduler()
+	if err := scheduler.Every(1).Day().At("10:30").StartImmediately().Do(func() {}); err == nil {
+		t.Error("StartImmediately with At should fail")
+	}
+	if err := scheduler.Every(1).Monday().StartAt(time.Now()).At("10:30").Do(func() {}); err == nil {
+		t.Error("StartAt with At should fail")
+	}
+	if err := scheduler.Every(1).Hour().StartAt(time.Now()).StartImmediately().Do(func() {}); err == nil {
+		t.Error("StartAt with StartImmediately should fail")
+	}
+}
@@ -19,13 +19,19 @@
 package gocron
 
 import (
+	"context"
 	"errors"
+	"math/rand"
 	"reflect"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
 )
 
 // UnitSeconds -
@@ -43,12 +49,20 @@ const UnitDays = "days"
 // UnitWeeks -
 const UnitWeeks = "weeks"
 
-// Time location, default set by the time.Local (*time.Location)
-var loc = time.Local
+// UnitMonths -
+const UnitMonths = "months"
+
+// unit of jobs scheduled with EveryDuration, their interval is in nanoseconds
+const unitDuration = "duration"
+
+// ErrVersionConflict - returned by the *IfVersion mutators when the job
+// changed since the caller read its version
+var ErrVersionConflict = errors.New("job version conflict")
 
-// ChangeLoc - Change the time location
+// ChangeLoc - Change the time location of jobs created from now on by the
+// default scheduler, see Scheduler.ChangeLoc
 func ChangeLoc(newLocation *time.Location) {
-	loc = newLocation
+	defaultScheduler().ChangeLoc(newLocation)
 }
 
 // Job -
@@ -73,49 +87,387 @@ type Job struct {
 
 	// Specific day of the week to start on
 	startDay time.Weekday
-
-	// Map for the function task store
-	funcs map[string]interface{}
-
-	// Map for function and  params of function
-	fparams map[string]([]interface{})
+	// the days of a job run on several weekdays, see Weekdays
+	weekdays weekdaySet
+
+	// the function to run and the params it is called with
+	fn     interface{}
+	params []interface{}
+	// unique within the process, see ID
+	id uint64
+
+	// jobs sharing an ordering key run serially on one lane
+	orderingKey string
+
+	// guards the job's state below and its version
+	mu sync.Mutex
+	// bumped by Reschedule, SetAt, Tag, Label, Name, Pause, Resume and
+	// DisableAfterPanics pausing the job, see Version
+	version uint64
+	// set by RemoveSelf, the scheduler drops the job on its next pass
+	removed bool
+
+	// paused jobs are not run, see Pause
+	paused    bool
+	pauseMode PauseMode
+	// time left until nextRun when the job was paused
+	remaining time.Duration
+
+	// first error hit while building the job, returned by Do
+	err error
+
+	// key=value labels, see Label
+	labels map[string]string
+	// tags to find jobs by, see Tag
+	tags []string
+	// shown by String instead of the function name, see Name
+	name string
+	// the scheduler's clock, see SetClock
+	clock clock.Clock
+	// position in the order jobs were added to the scheduler
+	seq uint64
+	// parsed At times, sorted
+	ats []timeOfDay
+	// offset within the hour or minute of hourly and minutely jobs using At
+	offset time.Duration
+	// the interval of EveryRandom jobs is drawn from interval to randomUpper
+	randomUpper uint64
+	// runs in a row that panicked, and the count that pauses the job, see DisableAfterPanics
+	panics, panicLimit int
+	// first run controls, see StartImmediately and StartAt
+	startNow bool
+	startAt  time.Time
+	// day of the month monthly jobs run on, see DayOfTheMonth
+	dayOfMonth int
+	// run on the first (1) or last (-1) monthWeekday of the month, see
+	// FirstWeekdayOfTheMonth
+	monthWeek    int
+	monthWeekday time.Weekday
+	// time location overriding the package one, see Loc
+	loc *time.Location
+	// keep the original schedule after missed runs, see RunMissed
+	runMissed bool
+
+	// the scheduler the job was created by, nil for NewJob
+	scheduler *Scheduler
+	// called with the results of runs, see OnError and OnSuccess
+	onError   func(error)
+	onSuccess func()
+	// calls after a run that returned an error, and the wait before the
+	// first of them, see Retry
+	retryAttempts int
+	retryBackoff  time.Duration
+	retries       int
+	// how long a run may take, see Timeout
+	timeout time.Duration
+	// called around runs, see SetEventListeners
+	beforeRun func(*Job)
+	afterRun  func(*Job, time.Duration)
+
+	// deep copy params for every run, see CopyParamsPerRun
+	copyParams bool
+	// fingerprint of the params, to spot runs changing them
+	paramsSum string
+	// indices of the params never shown, see RedactParams and Secret
+	redact []int
+	// how long runs took, see DurationSummary
+	durations durationStats
+
+	// objectives evaluated after runs, see SLO
+	slo *sloState
+	// schedule of jobs created with Cron
+	cron *cronSchedule
+
+	// never overlap runs, see SingletonMode
+	singleton       bool
+	singletonPolicy SingletonPolicy
+	// a singleton run is going, and one more is queued after it
+	running, pending bool
+	pendingDue       time.Time
+	pendingRun       int
+	skippedRuns      uint64
+
+	// runs so far, and the number after which the job is removed, see LimitRunsTo
+	runCount, limit int
+	// runs going and when the latest one started, see IsRunning and LastRun
+	active    int
+	startedAt time.Time
+	// shadow runs going, see InShadowRun
+	shadowRuns int
+	// goroutine the job was created on in debug mode, see building
+	creator uint64
+	// builder misuse from another goroutine was flagged
+	misused bool
 }
 
 // NewJob - Create a new job with the time interval.
 func NewJob(interval uint64) *Job {
 	return &Job{
-		interval,
-		"", "", "",
-		time.Unix(0, 0),
-		time.Unix(0, 0), 0,
-		time.Sunday,
-		make(map[string]interface{}),
-		make(map[string]([]interface{})),
+		interval: interval,
+		lastRun:  time.Unix(0, 0),
+		nextRun:  time.Unix(0, 0),
+		startDay: time.Sunday,
+		id:       atomic.AddUint64(&lastJobID, 1),
+		clock:    clock.Real,
+	}
+}
+
+// Decide whether the job is due at cutoff and if so claim its occurrence
+// for a run at now, in one step so that no other pass can dispatch the
+// same occurrence. A due job is skipped when skip is set.
+func (j *Job) claimDue(now, cutoff time.Time) (due bool, skip string, at time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !cutoff.After(j.nextRun) {
+		return false, "", time.Time{}
+	}
+	switch {
+	case j.jobFunc == "":
+		return true, SkipNotScheduled, time.Time{}
+	case j.paused:
+		return true, SkipPaused, time.Time{}
+	case j.overlapping():
+		j.claim(now)
+		j.skippedRuns++
+		return true, SkipRunning, time.Time{}
+	}
+	// a run dispatched early, within the lookahead, counts as on time
+	if now.Before(j.nextRun) {
+		now = j.nextRun
+	}
+	return true, "", j.claim(now)
+}
+
+// Whether the job is due at cutoff, without claiming it
+func (j *Job) dueBy(cutoff time.Time) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return cutoff.After(j.nextRun)
+}
+
+// Reschedule the job for a run at t of the occurrence at nextRun, before
+// the run is dispatched, returning the occurrence's due time. j.mu must
+// be held.
+func (j *Job) claim(t time.Time) (due time.Time) {
+	due = j.nextRun
+	j.sloDispatched(due)
+	j.scheduleAfterRun(due, t)
+	return due
+}
+
+// RemoveSelf - Remove the job from its scheduler. It is safe to call from
+// inside the job's own function: the job is never run again, and the
+// scheduler drops it on its next pass instead of while iterating its jobs.
+// The scheduler's Remove methods and Clear work the same way for a job
+// with a run going, e.g. when called from the job or the hooks around it.
+func (j *Job) RemoveSelf() {
+	j.mu.Lock()
+	j.removed = true
+	j.mu.Unlock()
+}
+
+func (j *Job) isRemoved() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.removed
+}
+
+// Call the job's function for the occurrence due at due, which has been
+// claimed already. When l is not nil the call is queued on that lane
+// instead of getting its own goroutine.
+func (j *Job) dispatch(l *lane, due time.Time) error {
+	j.mu.Lock()
+	if j.limit > 0 && j.runCount >= j.limit {
+		j.mu.Unlock()
+		return nil
+	}
+	start, accepted := j.admit(due)
+	if accepted {
+		j.countRun()
 	}
+	run := j.runCount
+	if accepted && !start {
+		j.pendingRun = run
+	}
+	j.mu.Unlock()
+	if !accepted {
+		j.report(JobSkipped)
+	}
+	if !start {
+		return nil
+	}
+	return j.start(l, due, run)
 }
 
-// True if the job should be run now
-func (j *Job) shouldRun() bool {
-	return time.Now().After(j.nextRun)
+// Start run number run, admitted by dispatch
+func (j *Job) start(l *lane, due time.Time, run int) error {
+	j.mu.Lock()
+	tk := jobTask{
+		name:       j.jobFunc,
+		fn:         reflect.ValueOf(j.fn),
+		params:     j.params,
+		copyParams: j.copyParams,
+		due:        due,
+		run:        run,
+		retries:    j.retryAttempts,
+		backoff:    j.retryBackoff,
+		clock:      j.clock,
+		timeout:    j.timeout,
+		late:       j.returnedLate,
+		retrying: func() {
+			j.mu.Lock()
+			j.retries++
+			j.mu.Unlock()
+		},
+	}
+	shadow := j.scheduler.shadowing()
+	if shadow {
+		s, params := j.scheduler, j.params
+		tk.shadow = func() { s.shadowRun(j, due, params) }
+	}
+	tk.started = func() {
+		j.mu.Lock()
+		j.active++
+		if shadow {
+			j.shadowRuns++
+		}
+		j.startedAt = j.now()
+		j.mu.Unlock()
+		j.runStarting()
+	}
+	j.mu.Unlock()
+
+	debug := !tk.copyParams && j.scheduler.debugging()
+	err := j.scheduler.executor().execute(tk, l, func(r runResult) {
+		if r.dropped {
+			j.mu.Lock()
+			j.uncountRun()
+			j.skippedRuns++
+			j.mu.Unlock()
+			j.report(JobSkipped)
+		} else {
+			j.finished(tk, due, r, debug)
+		}
+
+		j.mu.Lock()
+		next, queued := j.release()
+		nextRun := j.pendingRun
+		j.mu.Unlock()
+		if queued {
+			// not from this goroutine, which may be the lane's worker
+			tracker := j.scheduler.tracker()
+			tracker.add(tk.name)
+			j.scheduler.holdLane(l)
+			go func() {
+				defer tracker.done(tk.name)
+				defer j.scheduler.releaseLane(l)
+				j.start(l, next, nextRun)
+			}()
+		}
+	})
+	if err != nil {
+		j.mu.Lock()
+		j.pending = false
+		j.release()
+		if err == errAtLimit {
+			j.uncountRun()
+			j.skippedRuns++
+		}
+		j.mu.Unlock()
+		if err == errAtLimit {
+			j.report(JobSkipped)
+		}
+	}
+	return err
 }
 
-//Run the job and immediately reschedule it
-func (j *Job) run() (result []reflect.Value, err error) {
-	t := time.Now()
-	f := reflect.ValueOf(j.funcs[j.jobFunc])
-	params := j.fparams[j.jobFunc]
-	if len(params) != f.Type().NumIn() {
-		err = errors.New("the number of param is not adapted")
+// Record the result r of a run of tk that started, due at due
+func (j *Job) finished(tk jobTask, due time.Time, r runResult, debug bool) {
+	if r.shadow {
+		j.shadowFinished(r)
 		return
 	}
-	in := make([]reflect.Value, len(params))
-	for k, param := range params {
-		in[k] = reflect.ValueOf(param)
+	if debug {
+		j.checkParams(tk.params)
+	}
+	j.mu.Lock()
+	j.durations.observe(r.took)
+	j.mu.Unlock()
+	j.runReturned(r.took)
+	var err error
+	status := JobSuccess
+	if r.recovered != nil {
+		err, status = j.handlePanic(r), JobPanicked
+		if r.attempts > 1 {
+			err = j.handleResult(&RetriesExhaustedError{Attempts: r.attempts, Err: err})
+		}
+	} else {
+		j.mu.Lock()
+		j.panics = 0
+		j.mu.Unlock()
+		if err = j.handleResult(r.err()); err != nil {
+			status = JobFail
+		}
+	}
+	j.sloFinished(due, r.took, err)
+	if m, name := j.monitor(); m != nil {
+		m.RecordJobTiming(name, r.began, r.began.Add(r.took))
+		m.IncrementJob(name, status)
+	}
+	j.mu.Lock()
+	j.active--
+	j.mu.Unlock()
+}
+
+// Record a run at t of the occurrence due at due and schedule the next one
+func (j *Job) scheduleAfterRun(due, t time.Time) {
+	if j.runMissed {
+		j.realign(due, t)
+		j.lastRun = t
+		return
+	}
+	// count from the slot the run was due at, so that dispatch latency
+	// does not add up run after run, unless runs were missed and that
+	// puts the next one in the past
+	j.lastRun = due
+	j.scheduleNextRun()
+	if !j.nextRun.After(t) {
+		j.lastRun = t
+		j.scheduleNextRun()
 	}
-	go f.Call(in)
 	j.lastRun = t
+}
+
+// Set nextRun to the first slot of the job's schedule after now, counting
+// from the slot at due. lastRun is left as it was.
+func (j *Job) realign(due, now time.Time) {
+	lastRun := j.lastRun
+	defer func() { j.lastRun = lastRun }()
+
+	j.lastRun = due
 	j.scheduleNextRun()
-	return
+	if p := j.period; p > 0 && j.unit != UnitDays && j.unit != UnitWeeks && !j.nextRun.After(now) {
+		j.nextRun = j.nextRun.Add((now.Sub(j.nextRun)/p + 1) * p)
+	}
+	for !j.nextRun.After(now) {
+		prev := j.nextRun
+		j.lastRun = j.nextRun
+		j.scheduleNextRun()
+		if !j.nextRun.After(prev) {
+			return
+		}
+	}
+}
+
+// RunMissed - When the job comes due after missing runs, e.g. because the
+// machine slept, run it once and continue on its original schedule rather
+// than counting the next period from the late run. Missed runs are not
+// replayed either way.
+func (j *Job) RunMissed(on bool) *Job {
+	j.mu.Lock()
+	j.runMissed = on
+	j.mu.Unlock()
+	return j
 }
 
 // for given function fn, get the name of function.
@@ -123,25 +475,106 @@ func getFunctionName(fn interface{}) string {
 	return runtime.FuncForPC(reflect.ValueOf((fn)).Pointer()).Name()
 }
 
-// Do -Specifies the jobFunc that should be called every time the job runs
-func (j *Job) Do(jobFun interface{}, params ...interface{}) {
+// Err - the first error hit while building the job, if any
+func (j *Job) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// record the first error of the builder chain
+func (j *Job) setErr(err error) {
+	j.mu.Lock()
+	j.setErrLocked(err)
+	j.mu.Unlock()
+}
+
+// setErr for callers holding j.mu
+func (j *Job) setErrLocked(err error) {
+	if j.err == nil {
+		j.err = err
+	}
+}
+
+// Do -Specifies the jobFunc that should be called every time the job runs.
+// It returns the first error of the builder chain, or an error if jobFun
+// is not a function or params do not fit its signature; the job is not
+// scheduled in either case. A nil param passes the zero value of its type.
+func (j *Job) Do(jobFun interface{}, params ...interface{}) error {
+	if err := j.Err(); err != nil {
+		return err
+	}
+	if !j.building("Do") {
+		return j.Err()
+	}
 	typ := reflect.TypeOf(jobFun)
-	if typ.Kind() != reflect.Func {
-		panic("only function can be schedule into the job queue.")
+	if typ == nil || typ.Kind() != reflect.Func {
+		j.setErr(errors.New("only function can be schedule into the job queue"))
+		return j.Err()
+	}
+	params, secrets := revealSecrets(params)
+	if err := validateParams(typ, params); err != nil {
+		err = errors.New("Do() " + err.Error())
+		j.setErr(err)
+		return err
+	}
+	if err := j.checkFirstRun(); err != nil {
+		j.setErr(err)
+		return err
+	}
+	if err := j.checkRandom(); err != nil {
+		j.setErr(err)
+		return err
+	}
+	if err := j.checkDayOfMonth(); err != nil {
+		j.setErr(err)
+		return err
+	}
+	if err := j.checkRedact(len(params)); err != nil {
+		j.setErr(err)
+		return err
 	}
 
 	fname := getFunctionName(jobFun)
-	j.funcs[fname] = jobFun
-	j.fparams[fname] = params
+	// the fingerprint is only read by the debug checks, see checkParams
+	fingerprint := j.scheduler.debugging()
+	if s := j.scheduler; s != nil {
+		// checked and finalized in one go, see SetDuplicatePolicy
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.rejectDuplicate(j, fname, params); err != nil {
+			j.setErr(err)
+			return err
+		}
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.copyParams {
+		if _, err := copyParams(params); err != nil {
+			j.setErrLocked(err)
+			return err
+		}
+	}
+	if fingerprint {
+		j.paramsSum, _ = FingerprintParams(params...)
+	}
+	j.fn = jobFun
+	j.params = params
+	j.redact = append(j.redact, secrets...)
 	j.jobFunc = fname
 	//schedule the next run
 	j.scheduleNextRun()
+	j.applyFirstRun()
+	return nil
 }
 
-func formatTime(t string) (hour, min int, err error) {
+// Parse an At time, "hour:min" or "hour:min:sec". Minutes and seconds
+// take two digits, so "7:05" is fine but "7:5" is not. Second 60, a leap
+// second, is let through, see normalizeClock.
+func formatTime(t string) (hour, min, sec int, err error) {
 	var er = errors.New("time format error")
 	ts := strings.Split(t, ":")
-	if len(ts) != 2 {
+	if len(ts) != 2 && len(ts) != 3 {
 		err = er
 		return
 	}
@@ -154,95 +587,320 @@ func formatTime(t string) (hour, min int, err error) {
 	if err != nil {
 		return
 	}
+	if len(ts) == 3 {
+		sec, err = strconv.Atoi(ts[2])
+		if err != nil {
+			return
+		}
+	}
+	for _, field := range ts[1:] {
+		if len(field) != 2 {
+			err = errors.New("minutes and seconds take two digits")
+			return
+		}
+	}
 
-	if hour < 0 || hour > 23 || min < 0 || min > 59 {
-		err = er
+	if hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 60 {
+		err = errors.New("time out of range, want 00:00:00 to 23:59:59")
 		return
 	}
-	return hour, min, nil
+	return hour, min, sec, nil
 }
 
 // At - s.Every(1).Day().At("10:30").Do(task)
 // s.Every(1).Monday().At("10:30").Do(task)
+// Several times run the job at each of them, given either as a list,
+// At("09:00;13:00;17:30"), or by calling At again. Hourly jobs take the
+// minutes, and optionally seconds, past the hour, s.Every(1).Hour().At("30"),
+// and minutely jobs the seconds past the minute. A malformed time is
+// reported by Do.
 func (j *Job) At(t string) *Job {
-	hour, min, err := formatTime(t)
-	if err != nil {
-		panic(err)
+	if !j.building("At") {
+		return j
+	}
+	if j.cron != nil {
+		j.setErr(errors.New("At() cannot be used with a cron schedule"))
+		return j
+	}
+	if j.unit == UnitHours || j.unit == UnitMinutes {
+		j.atOffset(t)
+		return j
+	}
+	var times []timeOfDay
+	for _, part := range strings.Split(t, ";") {
+		hour, min, sec, err := formatTime(strings.TrimSpace(part))
+		if err != nil {
+			j.setErr(errors.New("invalid At time " + strconv.Quote(t) + ": " + err.Error()))
+			return j
+		}
+		c, leap := normalizeClock(hour, min, sec)
+		if leap {
+			j.logLeap(part, c.String())
+		}
+		times = append(times, c)
+	}
+	j.addAts(times)
+	if len(j.ats) == 1 && len(times) == 1 {
+		j.atTime = t
 	}
+	j.anchorAt()
+	return j
+}
 
-	// time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
-	mock := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), int(hour), int(min), 0, 0, loc)
+// Set lastRun to the latest At time before now, so that the next run
+// lands on the At time
+func (j *Job) anchorAt() {
+	if len(j.ats) == 0 || j.severalWeekdays() {
+		return
+	}
+	loc := j.location()
+	now := j.now().In(loc)
+	last := j.ats[len(j.ats)-1]
+	at := func(daysAgo int, c timeOfDay) time.Time {
+		return c.on(now.AddDate(0, 0, -daysAgo))
+	}
 
 	if j.unit == UnitDays {
-		if time.Now().After(mock) {
-			j.lastRun = mock
+		if c, ok := j.latestAtBefore(now); ok {
+			j.lastRun = at(0, c)
 		} else {
-			j.lastRun = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day()-1, hour, min, 0, 0, loc)
+			j.lastRun = at(1, last)
 		}
 	} else if j.unit == UnitWeeks {
-		if j.startDay != time.Now().Weekday() || (time.Now().After(mock) && j.startDay == time.Now().Weekday()) {
-			i := mock.Weekday() - j.startDay
+		back := j.weeksBack()
+		if j.startDay != now.Weekday() {
+			i := now.Weekday() - j.startDay
 			if i < 0 {
 				i = 7 + i
 			}
-			j.lastRun = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day()-int(i), hour, min, 0, 0, loc)
+			j.lastRun = at(int(i)+back, last)
+		} else if c, ok := j.latestAtBefore(now); ok {
+			j.lastRun = at(back, c)
 		} else {
-			j.lastRun = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day()-7, hour, min, 0, 0, loc)
+			j.lastRun = at(7+back, last)
 		}
 	}
+}
+
+// How many days before the latest start day a new weekly job's lastRun
+// goes, so that stepping interval weeks from it lands on the upcoming one.
+// The first run of every 2 weeks on Monday is the next Monday, and every
+// other Monday from there.
+func (j *Job) weeksBack() int {
+	return 7 * (int(j.interval) - 1)
+}
+
+// Loc - Compute the job's times in location l instead of the one set
+// with ChangeLoc, e.g. s.Every(1).Day().At("10:30").Loc(tokyo).Do(task)
+// It may be called before or after At and Do.
+func (j *Job) Loc(l *time.Location) *Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.loc = l
+	if j.atTime != "" {
+		j.anchorAt()
+	}
+	if j.jobFunc != "" {
+		j.scheduleNextRun()
+	}
 	return j
 }
 
+// The job's time location, falling back to the local time
+func (j *Job) location() *time.Location {
+	if j.loc != nil {
+		return j.loc
+	}
+	return time.Local
+}
+
 //Compute the instant when this job should run next
 func (j *Job) scheduleNextRun() {
-	if j.lastRun == time.Unix(0, 0) {
+	defer j.scheduler.wakeup()
+	if j.cron != nil {
+		from := j.lastRun
+		if from == time.Unix(0, 0) {
+			from = j.now()
+		}
+		j.nextRun = j.cron.next(from, j.location())
+		return
+	}
+	if j.unit == UnitMonths {
+		j.scheduleMonthly()
+		return
+	}
+	if j.severalWeekdays() {
+		j.scheduleWeekdays()
+		return
+	}
+	first := j.lastRun == time.Unix(0, 0)
+	if first {
 		if j.unit == UnitWeeks {
-			i := time.Now().Weekday() - j.startDay
+			now := j.now().In(j.location())
+			i := now.Weekday() - j.startDay
 			if i < 0 {
 				i = 7 + i
 			}
-			j.lastRun = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day()-int(i), 0, 0, 0, 0, loc)
+			j.lastRun = time.Date(now.Year(), now.Month(), now.Day()-int(i)-j.weeksBack(), 0, 0, 0, 0, j.location())
 
 		} else {
-			j.lastRun = time.Now()
+			j.lastRun = j.now()
 		}
 	}
 
-	if j.period != 0 {
-		// translate all the units to the Seconds
-		j.nextRun = j.lastRun.Add(j.period * time.Second)
-	} else {
+	if j.period == 0 || j.randomUpper > 0 {
+		interval := j.nextInterval()
 		switch j.unit {
 		case UnitMinutes:
-			j.period = time.Duration(j.interval * 60)
+			j.period = time.Duration(interval) * time.Minute
 			break
 		case UnitHours:
-			j.period = time.Duration(j.interval * 60 * 60)
+			j.period = time.Duration(interval) * time.Hour
 			break
 		case UnitDays:
-			j.period = time.Duration(j.interval * 60 * 60 * 24)
+			j.period = time.Duration(interval) * 24 * time.Hour
 			break
 		case UnitWeeks:
-			j.period = time.Duration(j.interval * 60 * 60 * 24 * 7)
+			j.period = time.Duration(interval) * 7 * 24 * time.Hour
 			break
 		case UnitSeconds:
-			j.period = time.Duration(j.interval)
+			j.period = time.Duration(interval) * time.Second
+		case unitDuration:
+			j.period = time.Duration(interval)
 		}
-		j.nextRun = j.lastRun.Add(j.period * time.Second)
+	}
+
+	switch j.unit {
+	case UnitDays, UnitWeeks:
+		// step by calendar days so the wall-clock time survives DST changes
+		last := j.lastRun.In(j.location())
+		days := int(j.period / (24 * time.Hour))
+		if len(j.ats) > 1 {
+			j.nextRun = j.nextAt(last, days)
+			break
+		}
+		j.nextRun = last.AddDate(0, 0, days)
+	case UnitHours, UnitMinutes:
+		if !j.hasOffset() {
+			j.nextRun = j.lastRun.Add(j.period)
+			break
+		}
+		// the slot at the offset a period on, or the first one for a new job
+		from := j.lastRun
+		if !first {
+			from = from.Add(j.period - unitLength(j.unit))
+		}
+		j.nextRun = j.nextOffset(from)
+	default:
+		j.nextRun = j.lastRun.Add(j.period)
 	}
 }
 
 // NextScheduledTime returns the time of when this job is to run next
 func (j *Job) NextScheduledTime() time.Time {
+	return j.next()
+}
+
+// TimeUntilNextRun - How long until the job's next run. ok is false for a
+// job that is not scheduled, because Do has not been called or the job
+// removed itself. The duration is negative for a job that is overdue,
+// e.g. because it is paused or waiting on its ordering lane.
+func (j *Job) TimeUntilNextRun() (d time.Duration, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.untilNextRun(j.now())
+}
+
+// requires j.mu held
+func (j *Job) untilNextRun(now time.Time) (time.Duration, bool) {
+	if j.jobFunc == "" || j.removed || j.nextRun.IsZero() {
+		return 0, false
+	}
+	return j.nextRun.Sub(now), true
+}
+
+func (j *Job) funcName() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.jobFunc
+}
+
+func (j *Job) next() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	return j.nextRun
 }
 
+// OrderingKey - Run the job serially with every other job sharing key.
+// Runs of such jobs are queued on a per-key lane in the order they come
+// due and executed one at a time, e.g.
+// s.Every(1).Day().At("02:00").OrderingKey("acme").Do(applyConfig)
+// s.Every(1).Day().At("02:00").OrderingKey("acme").Do(report)
+func (j *Job) OrderingKey(key string) *Job {
+	if !j.building("OrderingKey") {
+		return j
+	}
+	j.orderingKey = key
+	return j
+}
+
+// Version - the job's configuration version, bumped by every change made
+// with Reschedule, SetAt, Tag, Label, Name, Pause or Resume, and when
+// DisableAfterPanics pauses the job. Export saves it, Load carries it over
+// and PublishExpvar shows it.
+func (j *Job) Version() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.version
+}
+
+// Reschedule - Change the job to run every interval units, e.g.
+// job.Reschedule(5, UnitMinutes). The next run is recomputed from the last one.
+func (j *Job) Reschedule(interval uint64, unit string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.reschedule(interval, unit)
+}
+
+// RescheduleIfVersion - Reschedule only if the job is still at version,
+// returning ErrVersionConflict otherwise. Read Version, decide on the new
+// schedule and apply it with this to avoid clobbering a concurrent change.
+func (j *Job) RescheduleIfVersion(version uint64, interval uint64, unit string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.version != version {
+		return ErrVersionConflict
+	}
+	return j.reschedule(interval, unit)
+}
+
+func (j *Job) reschedule(interval uint64, unit string) error {
+	switch unit {
+	case UnitSeconds, UnitMinutes, UnitHours, UnitDays, UnitWeeks, UnitMonths:
+	default:
+		return errors.New("unknown time unit " + strconv.Quote(unit))
+	}
+	if interval == 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	j.interval = interval
+	j.unit = unit
+	j.period = 0
+	j.scheduleNextRun()
+	j.version++
+	return nil
+}
+
 // the follow functions set the job's unit with seconds,minutes,hours...
 
 // Second - Set the unit with second
 func (j *Job) Second() (job *Job) {
+	if !j.building("Second") {
+		return j
+	}
 	if j.interval != 1 {
-		panic("")
+		j.setErr(errors.New("Second() requires an interval of 1"))
+		return j
 	}
 	job = j.Seconds()
 	return
@@ -250,14 +908,21 @@ func (j *Job) Second() (job *Job) {
 
 // Seconds - Set the unit with seconds
 func (j *Job) Seconds() (job *Job) {
-	j.unit = UnitSeconds
+	if !j.building("Seconds") {
+		return j
+	}
+	j.setUnit("Seconds", UnitSeconds)
 	return j
 }
 
 // Minute - Set the unit  with minute, which interval is 1
 func (j *Job) Minute() (job *Job) {
+	if !j.building("Minute") {
+		return j
+	}
 	if j.interval != 1 {
-		panic("")
+		j.setErr(errors.New("Minute() requires an interval of 1"))
+		return j
 	}
 	job = j.Minutes()
 	return
@@ -265,14 +930,21 @@ func (j *Job) Minute() (job *Job) {
 
 // Minutes - Set the unit with minute
 func (j *Job) Minutes() (job *Job) {
-	j.unit = UnitMinutes
+	if !j.building("Minutes") {
+		return j
+	}
+	j.setUnit("Minutes", UnitMinutes)
 	return j
 }
 
 // Hour - Set the unit with hour, which interval is 1
 func (j *Job) Hour() (job *Job) {
+	if !j.building("Hour") {
+		return j
+	}
 	if j.interval != 1 {
-		panic("")
+		j.setErr(errors.New("Hour() requires an interval of 1"))
+		return j
 	}
 	job = j.Hours()
 	return
@@ -280,14 +952,21 @@ func (j *Job) Hour() (job *Job) {
 
 // Hours - Set the unit with hours
 func (j *Job) Hours() (job *Job) {
-	j.unit = UnitHours
+	if !j.building("Hours") {
+		return j
+	}
+	j.setUnit("Hours", UnitHours)
 	return j
 }
 
 // Day - Set the job's unit with day, which interval is 1
 func (j *Job) Day() (job *Job) {
+	if !j.building("Day") {
+		return j
+	}
 	if j.interval != 1 {
-		panic("")
+		j.setErr(errors.New("Day() requires an interval of 1"))
+		return j
 	}
 	job = j.Days()
 	return
@@ -295,213 +974,831 @@ func (j *Job) Day() (job *Job) {
 
 // Days - Set the job's unit with days
 func (j *Job) Days() *Job {
-	j.unit = UnitDays
+	if !j.building("Days") {
+		return j
+	}
+	j.setUnit("Days", UnitDays)
 	return j
 }
 
 // Monday - s.Every(1).Monday().Do(task)
-// Set the start day with Monday
+// Set the start day with Monday. A larger interval skips weeks,
+// s.Every(2).Monday() runs every other Monday starting with the next one.
 func (j *Job) Monday() (job *Job) {
-	if j.interval != 1 {
-		panic("")
-	}
-	j.startDay = 1
-	job = j.Weeks()
-	return
+	return j.weekday("Monday", time.Monday)
 }
 
 // Tuesday - Set the start day with Tuesday
 func (j *Job) Tuesday() (job *Job) {
-	if j.interval != 1 {
-		panic("")
-	}
-	j.startDay = 2
-	job = j.Weeks()
-	return
+	return j.weekday("Tuesday", time.Tuesday)
 }
 
 // Wednesday - Set the start day woth Wednesday
 func (j *Job) Wednesday() (job *Job) {
-	if j.interval != 1 {
-		panic("")
-	}
-	j.startDay = 3
-	job = j.Weeks()
-	return
+	return j.weekday("Wednesday", time.Wednesday)
 }
 
 // Thursday - Set the start day with thursday
 func (j *Job) Thursday() (job *Job) {
-	if j.interval != 1 {
-		panic("")
-	}
-	j.startDay = 4
-	job = j.Weeks()
-	return
+	return j.weekday("Thursday", time.Thursday)
 }
 
 // Friday - Set the start day with friday
 func (j *Job) Friday() (job *Job) {
-	if j.interval != 1 {
-		panic("")
-	}
-	j.startDay = 5
-	job = j.Weeks()
-	return
+	return j.weekday("Friday", time.Friday)
 }
 
 // Saturday - Set the start day with saturday
 func (j *Job) Saturday() (job *Job) {
-	if j.interval != 1 {
-		panic("")
-	}
-	j.startDay = 6
-	job = j.Weeks()
-	return
+	return j.weekday("Saturday", time.Saturday)
 }
 
 // Sunday - Set the start day with sunday
 func (j *Job) Sunday() (job *Job) {
-	if j.interval != 1 {
-		panic("")
+	return j.weekday("Sunday", time.Sunday)
+}
+
+// Weekday - Run the job on day d, like the day methods, e.g.
+// s.Every(2).Weekday(time.Monday).At("09:00").Do(task)
+func (j *Job) Weekday(d time.Weekday) *Job {
+	return j.weekday("Weekday", d)
+}
+
+func (j *Job) weekday(name string, d time.Weekday) *Job {
+	if !j.building(name) {
+		return j
 	}
-	j.startDay = 0
-	job = j.Weeks()
-	return
+	if d < time.Sunday || d > time.Saturday {
+		j.setErr(errors.New(name + "() takes a day from Sunday to Saturday"))
+		return j
+	}
+	j.Weeks()
+	j.addWeekday(name, d)
+	return j
 }
 
 // Weeks - Set the units as weeks
 func (j *Job) Weeks() *Job {
-	j.unit = UnitWeeks
+	if !j.building("Weeks") {
+		return j
+	}
+	j.setUnit("Weeks", UnitWeeks)
 	return j
 }
 
 // Scheduler  the only data member is the list of jobs.
 type Scheduler struct {
+	// guards jobs, so jobs can be added and removed while the scheduler runs
+	mu sync.RWMutex
 	// Array store jobs
 	jobs []*Job
+
+	// lanes for jobs with an ordering key, created on first use
+	laneMu       sync.Mutex
+	lanes        map[string]*lane
+	laneCapacity int
+	lanePolicy   LanePolicy
+
+	// jobs rendered by PublishExpvar
+	expvarLimit int
+
+	// numbers jobs in the order they are added
+	seq uint64
+	// whether Do accepts identical jobs, see SetDuplicatePolicy
+	duplicates DuplicatePolicy
+	// location given to new jobs, see ChangeLoc
+	loc *time.Location
+	// warnings go here, see SetLogger
+	logger Logger
+	// warn about Remove once
+	deprecateRemove sync.Once
+
+	// context of the running loop, passed to jobs taking one
+	ctx context.Context
+	// closed by Stop to end the loop, which closes loopDone on its way out
+	quit     chan struct{}
+	loopDone chan struct{}
+	// the channel Start returned for the running loop, see Start
+	stopped chan bool
+	// tells the loop the jobs changed and its timer may be off, see wakeup
+	wake chan struct{}
+	// runs that have not returned yet, see Wait
+	inflight inflight
+
+	// self-diagnostics, see SetDebug
+	debug bool
+	// no jobs are dispatched while paused, see Pause
+	paused bool
+
+	// called when a job returns an error, see SetErrorHandler
+	errorHandler func(*Job, error)
+	// called when a job's SLO changes state, see SetSLOHandler
+	sloHandler func(SLOEvent)
+	// called around every run, see SetEventListeners
+	beforeRun func(*Job)
+	afterRun  func(*Job, time.Duration)
+	// called when a job panics, see SetPanicHandler
+	panicHandler func(*Job, interface{}, []byte)
+	// told about every run, see SetMonitor
+	monitor Monitor
+	// called when a job moves in RefreshTimeZones, and the loader it
+	// uses, time.LoadLocation when nil
+	tzHandler    func(TimeZoneChange)
+	loadLocation func(string) (*time.Location, error)
+
+	// the last RunPending passes, see RecentDispatchPasses
+	passes dispatchLog
+	// how far ahead RunPending dispatches, see SetDispatchLookahead
+	lookahead time.Duration
+
+	// non-zero in shadow mode, see SetShadowMode
+	shadow       int32
+	shadowRecord atomic.Value
+
+	// when the loop last ended, and what Start then does with interval
+	// jobs, see SetResumePolicy
+	stoppedAt    time.Time
+	resumePolicy ResumePolicy
+
+	// one element per run going, when the number is limited, see
+	// SetMaxConcurrentJobs
+	slots     chan struct{}
+	limitMode LimitMode
+
+	// where the time comes from, see SetClock
+	clock clock.Clock
+	// settings of new jobs, see SingletonModeAll
+	defaults jobDefaults
+
+	// draws the intervals of EveryRandom jobs, see SetRandSource
+	rndMu sync.Mutex
+	rnd   *rand.Rand
 }
 
-// Scheduler implements the sort.Interface{} for sorting jobs, by the time nextRun
+// Scheduler implements the sort.Interface{} for sorting jobs, by the time nextRun.
+// The scheduler itself keeps jobs in the order they were added, sorting it
+// reorders Jobs. Each method takes the scheduler's lock, so sorting a
+// running scheduler is safe, though jobs added or removed meanwhile may
+// leave it partly sorted; JobsOrderedByNextRun gives a consistent order.
 
 func (s *Scheduler) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return len(s.jobs)
 }
 
 func (s *Scheduler) Swap(i, j int) {
-	s.jobs[i], s.jobs[j] = s.jobs[j], s.jobs[i]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i < len(s.jobs) && j < len(s.jobs) {
+		s.jobs[i], s.jobs[j] = s.jobs[j], s.jobs[i]
+	}
 }
 
 func (s *Scheduler) Less(i, j int) bool {
-	return s.jobs[j].nextRun.After(s.jobs[i].nextRun)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if i >= len(s.jobs) || j >= len(s.jobs) {
+		return false
+	}
+	return s.jobs[j].next().After(s.jobs[i].next())
 }
 
 // NewScheduler - Create a new scheduler
 func NewScheduler() *Scheduler {
-	return &Scheduler{}
+	return &Scheduler{
+		wake:         make(chan struct{}, 1),
+		laneCapacity: DefaultLaneCapacity,
+		lanePolicy:   LaneBlock,
+		expvarLimit:  DefaultExpvarJobLimit,
+		logger:       defaultLogger,
+		clock:        clock.Real,
+	}
 }
 
-// Get the current runnable jobs, which shouldRun is True
-func (s *Scheduler) getRunnableJobs() []*Job {
-	runnableJobs := []*Job{}
-	sort.Sort(s)
-	for i := 0; i < len(s.jobs); i++ {
-		if s.jobs[i].shouldRun() {
-			runnableJobs = append(runnableJobs, s.jobs[i])
-		} else {
+// ChangeLoc - Set the time location of jobs created by this scheduler
+// from now on, instead of the local time. Jobs can override it with Loc.
+func (s *Scheduler) ChangeLoc(newLocation *time.Location) {
+	s.mu.Lock()
+	s.loc = newLocation
+	s.mu.Unlock()
+}
+
+// SetLanePolicy - Set how many runs each ordering-key lane queues and
+// what happens once it is full. Lanes already in use keep their settings.
+func (s *Scheduler) SetLanePolicy(capacity int, policy LanePolicy) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	s.laneMu.Lock()
+	s.laneCapacity = capacity
+	s.lanePolicy = policy
+	s.laneMu.Unlock()
+}
+
+// SetDispatchLookahead - Dispatch jobs that come due within d after a
+// RunPending pass on that pass instead of the following one. Such runs are
+// recorded at their scheduled time, so the schedule does not drift early.
+// The default is 0, jobs never run before they are due.
+func (s *Scheduler) SetDispatchLookahead(d time.Duration) error {
+	if d < 0 {
+		return errors.New("dispatch lookahead must not be negative, got " + d.String())
+	}
+	s.mu.Lock()
+	s.lookahead = d
+	s.mu.Unlock()
+	return nil
+}
+
+// LaneDepth - Number of runs waiting on the lane for key
+func (s *Scheduler) LaneDepth(key string) int {
+	s.laneMu.Lock()
+	l, ok := s.lanes[key]
+	s.laneMu.Unlock()
+	if !ok {
+		return 0
+	}
+	return l.depth()
+}
+
+// Get the lane for the job's ordering key, nil if it has none. The lane
+// is held until releaseLane, so that it is not deleted before the run is
+// queued on it: lanes are deleted once idle so that keys can churn.
+func (s *Scheduler) laneFor(j *Job) *lane {
+	if j.orderingKey == "" {
+		return nil
+	}
+	s.laneMu.Lock()
+	defer s.laneMu.Unlock()
+	l, ok := s.lanes[j.orderingKey]
+	if !ok {
+		if s.lanes == nil {
+			s.lanes = make(map[string]*lane)
+		}
+		l = newLane(s.laneCapacity, s.lanePolicy)
+		l.key = j.orderingKey
+		l.onIdle = func() {
+			s.laneMu.Lock()
+			s.dropIdleLane(l)
+			s.laneMu.Unlock()
+		}
+		s.lanes[j.orderingKey] = l
+	}
+	l.refs++
+	return l
+}
+
+// Take another hold on l, which is held already, see laneFor
+func (s *Scheduler) holdLane(l *lane) {
+	if l == nil {
+		return
+	}
+	s.laneMu.Lock()
+	l.refs++
+	s.laneMu.Unlock()
+}
+
+// Let go of a hold on l taken by laneFor or holdLane
+func (s *Scheduler) releaseLane(l *lane) {
+	if l == nil {
+		return
+	}
+	s.laneMu.Lock()
+	l.refs--
+	s.dropIdleLane(l)
+	s.laneMu.Unlock()
+}
+
+// Delete l once no run is going or waiting on it and nothing holds it,
+// requires s.laneMu held
+func (s *Scheduler) dropIdleLane(l *lane) {
+	if l.refs == 0 && s.lanes[l.key] == l && l.idle() {
+		delete(s.lanes, l.key)
+	}
+}
+
+// Get the current runnable jobs, which shouldRun is True, noting the
+// decisions in pass
+func (s *Scheduler) getRunnableJobs(pass *DispatchPass) (runnableJobs []*Job, dues []time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runnableJobs = []*Job{}
+	s.sweep()
+	jobs := s.byNextRun()
+	pass.Scanned = len(jobs)
+	cutoff := pass.Start.Add(s.lookahead)
+	for _, job := range jobs {
+		if s.paused {
+			if !job.dueBy(cutoff) {
+				break
+			}
+			pass.Due++
+			pass.Skipped = append(pass.Skipped, SkippedRun{Job: job, Reason: SkipPaused})
+			continue
+		}
+		due, skip, at := job.claimDue(pass.Start, cutoff)
+		if !due {
 			break
 		}
+		pass.Due++
+		if skip != "" {
+			pass.Skipped = append(pass.Skipped, SkippedRun{Job: job, Reason: skip})
+			continue
+		}
+		runnableJobs = append(runnableJobs, job)
+		dues = append(dues, at)
 	}
-	return runnableJobs
+	return runnableJobs, dues
+}
+
+// Run the job's claimed occurrence due at due, which only records it in
+// shadow mode. errAtLimit means the run was dropped under LimitReschedule.
+func (s *Scheduler) runJob(j *Job, due time.Time) error {
+	l := s.laneFor(j)
+	defer s.releaseLane(l)
+	return j.dispatch(l, due)
+}
+
+// Claim the job's next occurrence and run it now, reporting whether it
+// is scheduled
+func (s *Scheduler) runNow(j *Job) bool {
+	j.mu.Lock()
+	if j.jobFunc == "" || j.removed {
+		j.mu.Unlock()
+		return false
+	}
+	due := j.claim(j.now())
+	j.mu.Unlock()
+	s.runJob(j, due)
+	return true
+}
+
+// Drop the jobs that asked to be removed with RemoveSelf, s.mu must be held
+func (s *Scheduler) sweep() {
+	kept := s.jobs[:0]
+	for _, job := range s.jobs {
+		if !job.isRemoved() {
+			kept = append(kept, job)
+		}
+	}
+	for i := len(kept); i < len(s.jobs); i++ {
+		s.jobs[i] = nil
+	}
+	s.jobs = kept
+}
+
+// Copy of the jobs, for running them without holding the lock
+func (s *Scheduler) snapshot() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	return append([]*Job(nil), s.jobs...)
+}
+
+// JobsOrderedByNextRun - A copy of the scheduled jobs, soonest first.
+// Jobs due at the same time keep the order they were added in.
+func (s *Scheduler) JobsOrderedByNextRun() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	return s.byNextRun()
+}
+
+// Copy of the jobs sorted by nextRun, leaving s.jobs in the order they
+// were added, s.mu must be held
+func (s *Scheduler) byNextRun() []*Job {
+	jobs := append([]*Job(nil), s.jobs...)
+	next := make([]time.Time, len(jobs))
+	for i, job := range jobs {
+		next[i] = job.next()
+	}
+	sort.Stable(byTime{jobs, next})
+	return jobs
+}
+
+// Sorts jobs by the times next, read once so they cannot change mid-sort
+type byTime struct {
+	jobs []*Job
+	next []time.Time
+}
+
+func (b byTime) Len() int           { return len(b.jobs) }
+func (b byTime) Less(i, j int) bool { return b.next[i].Before(b.next[j]) }
+func (b byTime) Swap(i, j int) {
+	b.jobs[i], b.jobs[j] = b.jobs[j], b.jobs[i]
+	b.next[i], b.next[j] = b.next[j], b.next[i]
 }
 
 // NextRun - Datetime when the next job should run.
 func (s *Scheduler) NextRun() (*Job, time.Time) {
-	if len(s.jobs) <= 0 {
-		return nil, time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	for _, job := range s.byNextRun() {
+		if job.isFinalized() {
+			return job, job.next()
+		}
 	}
-	sort.Sort(s)
-	return s.jobs[0], s.jobs[0].nextRun
+	return nil, s.clock.Now()
 }
 
 // Every - Schedule a new periodic job
 func (s *Scheduler) Every(interval uint64) *Job {
 	job := NewJob(interval)
+	s.mu.Lock()
+	s.seq++
+	job.seq = s.seq
+	job.loc = s.loc
+	job.clock = s.clock
+	job.scheduler = s
+	s.applyDefaults(job)
+	if s.debug {
+		job.creator = goroutineID()
+	}
 	s.jobs = append(s.jobs, job)
+	s.mu.Unlock()
+	s.wakeup()
 	return job
 }
 
+// EveryDuration - Schedule a new job that runs every d, which may be finer
+// than a second, e.g. s.EveryDuration(250 * time.Millisecond).Do(task)
+func (s *Scheduler) EveryDuration(d time.Duration) *Job {
+	job := s.Every(uint64(d))
+	if d <= 0 {
+		job.setErr(errors.New("EveryDuration() requires a positive duration, got " + d.String()))
+		return job
+	}
+	job.unit = unitDuration
+	return job
+}
+
+// RunEvery - Schedule fn to run every d, like EveryDuration(d).Do(fn)
+func (s *Scheduler) RunEvery(d time.Duration, fn func()) (*Job, error) {
+	if fn == nil {
+		return nil, errors.New("nil job function")
+	}
+	if d <= 0 {
+		return nil, errors.New("interval must be positive, got " + d.String())
+	}
+	return s.runHelper(s.EveryDuration(d), fn)
+}
+
+// RunDailyAt - Schedule fn to run every day at the "hour:min" time at
+func (s *Scheduler) RunDailyAt(at string, fn func()) (*Job, error) {
+	if fn == nil {
+		return nil, errors.New("nil job function")
+	}
+	if _, _, _, err := formatTime(at); err != nil {
+		return nil, err
+	}
+	return s.runHelper(s.Every(1).Day().At(at), fn)
+}
+
+// Finish a job of the Run helpers with Do(fn), removing it again if that fails
+func (s *Scheduler) runHelper(job *Job, fn func()) (*Job, error) {
+	if err := job.Do(fn); err != nil {
+		s.RemoveByReference(job)
+		return nil, err
+	}
+	return job, nil
+}
+
 // RunPending - Run all the jobs that are scheduled to run.
 func (s *Scheduler) RunPending() {
-	runnableJobs := s.getRunnableJobs()
+	s.runPendingAt(s.now())
+}
+
+// One RunPending pass with the clock reading now
+func (s *Scheduler) runPendingAt(now time.Time) *DispatchPass {
+	began := time.Now()
+	pass := &DispatchPass{
+		Start:  now,
+		Shadow: atomic.LoadInt32(&s.shadow) != 0,
+	}
+	runnableJobs, dues := s.getRunnableJobs(pass)
+	for _, skipped := range pass.Skipped {
+		if skipped.Reason == SkipRunning {
+			skipped.Job.report(JobSkipped)
+		}
+	}
 
-	for _, job := range runnableJobs {
-		job.run()
+	for i, job := range runnableJobs {
+		if err := s.runJob(job, dues[i]); err == errAtLimit {
+			pass.Skipped = append(pass.Skipped, SkippedRun{Job: job, Reason: SkipLimit})
+			continue
+		}
+		pass.Dispatched = append(pass.Dispatched, job)
 	}
+	pass.Duration = time.Since(began)
+	s.passes.record(pass)
+	return pass
 }
 
 // RunAll - Run all jobs regardless if they are scheduled to run or not
 func (s *Scheduler) RunAll() {
-	for _, job := range s.jobs {
-		job.run()
+	for _, job := range s.snapshot() {
+		s.runNow(job)
 	}
 }
 
 // RunAllwithDelay - Run all jobs with delay seconds
+//
+// Deprecated: use RunAllWithDelay, which takes a time.Duration.
 func (s *Scheduler) RunAllwithDelay(d int) {
-	for _, job := range s.jobs {
-		job.run()
-		time.Sleep(time.Duration(d))
-	}
+	s.RunAllWithDelay(time.Duration(d)*time.Second, RunReschedule)
 }
 
-// Remove specific job j
-func (s *Scheduler) Remove(j interface{}) {
-	var i int
-	var job *Job
-	for i, job = range s.jobs {
-		if job.jobFunc == getFunctionName(j) {
-			break
+// Remove every job scheduled with function j, reporting whether there was any
+//
+// Deprecated: use RemoveAllByFunction, or RemoveFirstByFunction to keep
+// all but one of the jobs running j.
+func (s *Scheduler) Remove(j interface{}) bool {
+	s.deprecateRemove.Do(func() {
+		s.logf("Remove is deprecated, use RemoveFirstByFunction or RemoveAllByFunction")
+	})
+	return s.RemoveAllByFunction(j) > 0
+}
+
+// RemoveByReference - Remove the job j, reporting whether it was scheduled
+func (s *Scheduler) RemoveByReference(j *Job) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeMatching(func(job *Job) bool { return job == j }, false) > 0
+}
+
+// Remove the job j, requires s.mu held
+func (s *Scheduler) removeLocked(j *Job) bool {
+	for i, job := range s.jobs {
+		if job == j {
+			s.jobs = append(s.jobs[:i], s.jobs[i+1:]...)
+			s.wakeup()
+			return true
 		}
 	}
+	return false
+}
+
+// RemoveFirstByFunction - Remove the earliest added job that runs fn,
+// reporting whether there was one
+func (s *Scheduler) RemoveFirstByFunction(fn interface{}) bool {
+	name := getFunctionName(fn)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeMatching(func(job *Job) bool { return job.funcName() == name }, true) > 0
+}
+
+// RemoveAllByFunction - Remove every job that runs fn, returning how many were removed
+func (s *Scheduler) RemoveAllByFunction(fn interface{}) int {
+	name := getFunctionName(fn)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeMatching(func(job *Job) bool { return job.funcName() == name }, false)
+}
 
-	s.jobs = append(s.jobs[:i], s.jobs[i+1:]...)
+// Remove the jobs match picks, or only the earliest added one with first,
+// returning how many were. A job with a run going is only marked removed,
+// for the next pass to drop once the run's bookkeeping is done, see
+// RemoveSelf. Requires s.mu held.
+func (s *Scheduler) removeMatching(match func(*Job) bool, first bool) int {
+	if first {
+		var earliest *Job
+		for _, job := range s.jobs {
+			if !job.isRemoved() && match(job) && (earliest == nil || job.seq < earliest.seq) {
+				earliest = job
+			}
+		}
+		match = func(job *Job) bool { return job == earliest }
+	}
+	kept := s.jobs[:0]
+	removed := 0
+	for _, job := range s.jobs {
+		switch {
+		case job.isRemoved() || !match(job):
+			kept = append(kept, job)
+		case job.IsRunning():
+			job.RemoveSelf()
+			kept = append(kept, job)
+			removed++
+		default:
+			removed++
+		}
+	}
+	for i := len(kept); i < len(s.jobs); i++ {
+		s.jobs[i] = nil
+	}
+	s.jobs = kept
+	if removed > 0 {
+		s.wakeup()
+	}
+	return removed
 }
 
 // Clear - Delete all scheduled jobs
 func (s *Scheduler) Clear() {
-	s.jobs = []*Job{}
+	s.mu.Lock()
+	s.removeMatching(func(*Job) bool { return true }, false)
+	s.mu.Unlock()
 }
 
 // Start all the pending jobs
 // Add seconds ticker
+// Starting a running scheduler starts no second loop, the channel
+// returned stops the one running.
 func (s *Scheduler) Start() chan bool {
 	stopped := make(chan bool, 1)
-	ticker := time.NewTicker(1 * time.Second)
-
+	loop := s.start(context.Background(), stopped)
+	if loop != nil {
+		go loop()
+		return stopped
+	}
+	s.mu.RLock()
+	running, loopDone := s.stopped, s.loopDone
+	s.mu.RUnlock()
+	if running != nil {
+		return running
+	}
 	go func() {
+		select {
+		case <-stopped:
+			s.Stop()
+		case <-loopDone:
+		}
+	}()
+	return stopped
+}
+
+// StartWithContext - Start all the pending jobs until ctx is done or Stop
+// is called. Jobs whose function takes a context.Context as its first
+// parameter are passed ctx, ahead of the params given to Do. It does
+// nothing on a running scheduler.
+func (s *Scheduler) StartWithContext(ctx context.Context) {
+	if loop := s.start(ctx, nil); loop != nil {
+		go loop()
+	}
+}
+
+// Set up a loop running the jobs until ctx is done, Stop is called or
+// stopped receives, returning it to be run, or nil if the scheduler is
+// running already
+func (s *Scheduler) start(ctx context.Context, stopped chan bool) (loop func()) {
+	quit := make(chan struct{})
+	loopDone := make(chan struct{})
+	s.mu.Lock()
+	if s.loopDone != nil {
+		select {
+		case <-s.loopDone:
+		default:
+			s.mu.Unlock()
+			return nil
+		}
+	}
+	s.ctx = ctx
+	s.stopped = stopped
+	s.quit = quit
+	s.loopDone = loopDone
+	stoppedAt := s.stoppedAt
+	c := s.clock
+	s.mu.Unlock()
+	s.warnUnfinalized()
+	if !stoppedAt.IsZero() {
+		s.resume(stoppedAt, c.Now())
+	}
+	ticker := c.NewTicker(s.untilNextWake())
+
+	return func() {
+		defer close(loopDone)
+		defer func() {
+			s.mu.Lock()
+			s.stoppedAt = c.Now()
+			s.mu.Unlock()
+		}()
+		defer ticker.Stop()
 		for {
 			select {
-			case <-ticker.C:
+			case <-ticker.C():
 				s.RunPending()
+				s.checkSLOs(c.Now())
+				// the runs just dispatched rescheduled themselves
+				select {
+				case <-s.wake:
+				default:
+				}
+				ticker.Reset(s.untilNextWake())
+			case <-s.wake:
+				ticker.Reset(s.untilNextWake())
 			case <-stopped:
 				return
+			case <-quit:
+				return
+			case <-ctx.Done():
+				return
 			}
 		}
-	}()
+	}
+}
 
-	return stopped
+// maxWake is the longest the loop sleeps. Changes to the jobs wake it up
+// early, this only bounds how late it notices the wall clock jumping.
+const maxWake = time.Minute
+
+// Tell the loop to recompute when it should wake up. Safe on a nil scheduler.
+func (s *Scheduler) wakeup() {
+	if s == nil {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// How long the loop sleeps before the next job it can run is due, or an
+// SLO completion window closes
+func (s *Scheduler) untilNextWake() time.Duration {
+	s.mu.RLock()
+	lookahead, paused := s.lookahead, s.paused
+	s.mu.RUnlock()
+	if paused {
+		// Resume wakes the loop up
+		return maxWake
+	}
+	now := s.now()
+	wait := maxWake
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		if job.jobFunc != "" && !job.paused && !job.removed {
+			if d := job.nextRun.Sub(now) - lookahead; d < wait {
+				wait = d
+			}
+			if st := job.slo; st != nil && !st.violated[SLOMustCompleteWithin] {
+				if deadline, ok := st.deadline(job.nextRun); ok {
+					if d := deadline.Sub(now); d < wait {
+						wait = d
+					}
+				}
+			}
+		}
+		job.mu.Unlock()
+	}
+	// RunPending runs jobs strictly after their next run time
+	if wait < time.Millisecond {
+		wait = time.Millisecond
+	}
+	return wait
 }
 
 // The following methods are shortcuts for not having to
 // create a Schduler instance
 
-var defaultScheduler = NewScheduler()
-var jobs = defaultScheduler.jobs
+var (
+	defaultMu    sync.RWMutex
+	defaultSched = NewScheduler()
+)
+
+// The scheduler behind the package-level functions
+func defaultScheduler() *Scheduler {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultSched
+}
+
+// DefaultScheduler - The scheduler the package-level functions use
+func DefaultScheduler() *Scheduler {
+	return defaultScheduler()
+}
+
+// SetDefaultScheduler - Make the package-level functions use s, e.g. a
+// scheduler owned by a test, nil for a new one. The previous scheduler
+// keeps its jobs and is not stopped; calls already made on it are not
+// redirected.
+func SetDefaultScheduler(s *Scheduler) {
+	if s == nil {
+		s = NewScheduler()
+	}
+	defaultMu.Lock()
+	defaultSched = s
+	defaultMu.Unlock()
+}
 
 // Every - Schedule a new periodic job
 func Every(interval uint64) *Job {
-	return defaultScheduler.Every(interval)
+	return defaultScheduler().Every(interval)
+}
+
+// EveryDuration - Schedule a new job that runs every d on the default scheduler
+func EveryDuration(d time.Duration) *Job {
+	return defaultScheduler().EveryDuration(d)
+}
+
+// RunEvery - Schedule fn to run every d on the default scheduler
+func RunEvery(d time.Duration, fn func()) (*Job, error) {
+	return defaultScheduler().RunEvery(d, fn)
+}
+
+// RunDailyAt - Schedule fn to run every day at the "hour:min" time at on the default scheduler
+func RunDailyAt(at string, fn func()) (*Job, error) {
+	return defaultScheduler().RunDailyAt(at, fn)
 }
 
 // RunPending - Run all jobs that are scheduled to run
@@ -512,12 +1809,12 @@ func Every(interval uint64) *Job {
 // in one hour increments then your job won't be run 60 times in
 // between but only once.
 func RunPending() {
-	defaultScheduler.RunPending()
+	defaultScheduler().RunPending()
 }
 
 // RunAll - Run all jobs regardless if they are scheduled to run or not.
 func RunAll() {
-	defaultScheduler.RunAll()
+	defaultScheduler().RunAll()
 }
 
 // RunAllwithDelay - Run all the jobs with a delay in seconds
@@ -525,26 +1822,71 @@ func RunAll() {
 // A delay of `delay` seconds is added between each job. This can help
 // to distribute the system load generated by the jobs more evenly over
 // time.
+//
+// Deprecated: use RunAllWithDelay, which takes a time.Duration.
 func RunAllwithDelay(d int) {
-	defaultScheduler.RunAllwithDelay(d)
+	defaultScheduler().RunAllwithDelay(d)
+}
+
+// RunAllWithDelay - Run all the jobs of the default scheduler, one every d
+func RunAllWithDelay(d time.Duration, m ...RunMode) {
+	defaultScheduler().RunAllWithDelay(d, m...)
 }
 
-// Start - Run all jobs that are scheduled to run
+// Start - Run all jobs that are scheduled to run on the default
+// scheduler. Calling it again while it runs returns the same channel.
 func Start() chan bool {
-	return defaultScheduler.Start()
+	return defaultScheduler().Start()
+}
+
+// Stop - Stop the default scheduler started with Start
+func Stop() {
+	defaultScheduler().Stop()
 }
 
 // Clear -
 func Clear() {
-	defaultScheduler.Clear()
+	defaultScheduler().Clear()
 }
 
 // Remove -
-func Remove(j interface{}) {
-	defaultScheduler.Remove(j)
+//
+// Deprecated: use RemoveFirstByFunction or RemoveAllByFunction.
+func Remove(j interface{}) bool {
+	return defaultScheduler().Remove(j)
+}
+
+// RemoveByReference - Remove the job j from the default scheduler
+func RemoveByReference(j *Job) bool {
+	return defaultScheduler().RemoveByReference(j)
+}
+
+// RemoveFirstByFunction - Remove the earliest added job that runs fn from the default scheduler
+func RemoveFirstByFunction(fn interface{}) bool {
+	return defaultScheduler().RemoveFirstByFunction(fn)
+}
+
+// RemoveAllByFunction - Remove every job that runs fn from the default scheduler
+func RemoveAllByFunction(fn interface{}) int {
+	return defaultScheduler().RemoveAllByFunction(fn)
 }
 
 // NextRun gets the next running time
 func NextRun() (job *Job, time time.Time) {
-	return defaultScheduler.NextRun()
+	return defaultScheduler().NextRun()
+}
+
+// Jobs - The jobs of the default scheduler
+func Jobs() []*Job {
+	return defaultScheduler().Jobs()
+}
+
+// RunByTag - Run the default scheduler's jobs tagged with tag now, see Scheduler.RunByTag
+func RunByTag(tag string, m ...RunMode) error {
+	return defaultScheduler().RunByTag(tag, m...)
+}
+
+// RemoveByTag - Remove every job of the default scheduler tagged with tag
+func RemoveByTag(tag string) error {
+	return defaultScheduler().RemoveByTag(tag)
 }
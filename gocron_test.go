@@ -17,10 +17,11 @@ func taskWithParams(a int, b string) {
 }
 
 func TestSecond(*testing.T) {
-	defaultScheduler.Every(1).Second().Do(task)
-	defaultScheduler.Every(1).Second().Do(taskWithParams, 1, "hello")
-	defaultScheduler.Start()
+	defaultScheduler().Every(1).Second().Do(task)
+	defaultScheduler().Every(1).Second().Do(taskWithParams, 1, "hello")
+	stopped := defaultScheduler().Start()
 	time.Sleep(10 * time.Second)
+	stopped <- true
 }
 
 // This is a basic test for the issue described here: https://github.com/jasonlvhit/gocron/issues/23
@@ -84,6 +85,7 @@ func Test_formatTime(t *testing.T) {
 		args     string
 		wantHour int
 		wantMin  int
+		wantSec  int
 		wantErr  bool
 	}{
 		{
@@ -115,12 +117,57 @@ func Test_formatTime(t *testing.T) {
 			wantErr:  true,
 		},
 		{
-			name:     "wrongformat",
+			name:     "seconds",
 			args:     "19:18:17",
+			wantHour: 19,
+			wantMin:  18,
+			wantSec:  17,
+			wantErr:  false,
+		},
+		{
+			name:     "leadingzeros",
+			args:     "07:05:00",
+			wantHour: 7,
+			wantMin:  5,
+			wantErr:  false,
+		},
+		{
+			name:     "wrongformat",
+			args:     "19:18:17:16",
 			wantHour: 0,
 			wantMin:  0,
 			wantErr:  true,
 		},
+		{
+			name:     "midnightend",
+			args:     "24:00",
+			wantHour: 24,
+			wantMin:  0,
+			wantErr:  true,
+		},
+		{
+			name:     "shortminute",
+			args:     "7:5",
+			wantHour: 7,
+			wantMin:  5,
+			wantErr:  true,
+		},
+		{
+			name:     "leapsecond",
+			args:     "19:18:60",
+			wantHour: 19,
+			wantMin:  18,
+			wantSec:  60,
+			wantErr:  false,
+		},
+		{
+			name:     "wrongsecond",
+			args:     "19:18:61",
+			wantHour: 19,
+			wantMin:  18,
+			wantSec:  61,
+			wantErr:  true,
+		},
 		{
 			name:     "wrongminute",
 			args:     "19:1e",
@@ -131,7 +178,7 @@ func Test_formatTime(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotHour, gotMin, err := formatTime(tt.args)
+			gotHour, gotMin, gotSec, err := formatTime(tt.args)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("formatTime() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -142,6 +189,9 @@ func Test_formatTime(t *testing.T) {
 			if gotMin != tt.wantMin {
 				t.Errorf("formatTime() gotMin = %v, want %v", gotMin, tt.wantMin)
 			}
+			if gotSec != tt.wantSec {
+				t.Errorf("formatTime() gotSec = %v, want %v", gotSec, tt.wantSec)
+			}
 		})
 	}
 }
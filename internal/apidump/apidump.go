@@ -0,0 +1,147 @@
+// Package apidump describes the exported API of a Go package as a sorted
+// list of lines, one per constant, variable, function, type, exported
+// struct field and method, so two versions of a package can be diffed.
+package apidump
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Dir type-checks the package in dir and returns the lines describing its
+// exported API. Files with build constraints are left out, so that the
+// API described is the one every supported toolchain builds; the files
+// must not be needed by the untagged ones.
+func Dir(dir string) ([]string, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if !constrained(f) {
+			files = append(files, f)
+		}
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(bp.ImportPath, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+	return Package(pkg), nil
+}
+
+// Whether f has a build constraint, a //go:build or // +build line
+// before its package clause
+func constrained(f *ast.File) bool {
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, "//go:build ") || strings.HasPrefix(c.Text, "// +build ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Package returns the lines describing the exported API of pkg
+func Package(pkg *types.Package) []string {
+	qualifier := func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return other.Path()
+	}
+
+	var lines []string
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		switch obj := obj.(type) {
+		case *types.Const:
+			lines = append(lines, "const "+name+" "+types.TypeString(obj.Type(), qualifier))
+		case *types.Var:
+			lines = append(lines, "var "+name+" "+types.TypeString(obj.Type(), qualifier))
+		case *types.Func:
+			lines = append(lines, types.ObjectString(obj, qualifier))
+		case *types.TypeName:
+			lines = append(lines, typeLines(obj, qualifier)...)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func typeLines(obj *types.TypeName, qualifier types.Qualifier) []string {
+	name := obj.Name()
+	under := obj.Type().Underlying()
+
+	var lines []string
+	switch under := under.(type) {
+	case *types.Struct:
+		lines = append(lines, "type "+name+" struct")
+		for i := 0; i < under.NumFields(); i++ {
+			field := under.Field(i)
+			if field.Exported() {
+				lines = append(lines, "field "+name+"."+field.Name()+" "+types.TypeString(field.Type(), qualifier))
+			}
+		}
+	default:
+		lines = append(lines, "type "+name+" "+types.TypeString(under, qualifier))
+	}
+
+	if _, ok := under.(*types.Interface); ok {
+		return lines
+	}
+	mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+	for i := 0; i < mset.Len(); i++ {
+		method := mset.At(i).Obj()
+		if method.Exported() {
+			lines = append(lines, strings.Replace(types.ObjectString(method, qualifier), "func ", "method ", 1))
+		}
+	}
+	return lines
+}
+
+// Diff compares the API lines of an old and a new version of a package.
+// removed holds the lines missing from new, which covers both removals
+// and signature changes; added holds the lines new to it.
+func Diff(old, new []string) (removed, added []string) {
+	inOld := make(map[string]bool, len(old))
+	for _, line := range old {
+		inOld[line] = true
+	}
+	inNew := make(map[string]bool, len(new))
+	for _, line := range new {
+		inNew[line] = true
+		if !inOld[line] {
+			added = append(added, line)
+		}
+	}
+	for _, line := range old {
+		if !inNew[line] {
+			removed = append(removed, line)
+		}
+	}
+	return removed, added
+}
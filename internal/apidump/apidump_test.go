@@ -0,0 +1,105 @@
+package apidump
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const src = `package p
+
+const Answer = 42
+
+var ErrBad error
+
+type Kind int
+
+func (k Kind) String() string { return "" }
+
+type Point struct {
+	X, Y   int
+	hidden bool
+}
+
+func (p *Point) Move(dx int) *Point { return p }
+func (p *Point) reset()            {}
+
+type Mover interface {
+	Move(dx int) *Point
+}
+
+func New(x int) Point { return Point{} }
+func helper()          {}
+`
+
+func check(t *testing.T, src string) *types.Package {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestPackage(t *testing.T) {
+	got := Package(check(t, src))
+	want := []string{
+		"const Answer untyped int",
+		"field Point.X int",
+		"field Point.Y int",
+		"func New(x int) Point",
+		"method (*Point).Move(dx int) *Point",
+		"method (Kind).String() string",
+		"type Kind int",
+		"type Mover interface{Move(dx int) *Point}",
+		"type Point struct",
+		"var ErrBad error",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Package() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := []string{"func A()", "func B(x int)", "type T int"}
+	new := []string{"func A()", "func B(x string)", "func C()", "type T int"}
+	removed, added := Diff(old, new)
+	if !reflect.DeepEqual(removed, []string{"func B(x int)"}) {
+		t.Errorf("removed = %q", removed)
+	}
+	if !reflect.DeepEqual(added, []string{"func B(x string)", "func C()"}) {
+		t.Errorf("added = %q", added)
+	}
+}
+
+func TestDirSkipsTaggedFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"p.go":      "package p\n\nfunc Always() {}\n",
+		"new.go":    "//go:build go1.1\n\npackage p\n\nfunc Newer() {}\n",
+		"legacy.go": "// +build go1.1\n\npackage p\n\nfunc Legacy() {}\n",
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := Dir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"func Always()"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dir() = %q, want %q", got, want)
+	}
+}
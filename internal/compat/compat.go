@@ -0,0 +1,40 @@
+// Package compat holds the thin shims that let gocron use newer standard
+// library features while still building with the oldest Go it supports.
+// Each feature has one file per side of its build tag, and the fallbacks
+// live untagged here so they are tested on every toolchain.
+package compat
+
+import "strings"
+
+// joined is the fallback of errors.Join for toolchains before go1.20
+type joined struct {
+	errs []error
+}
+
+func (e *joined) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap is what errors.Is and errors.As use from go1.20 on
+func (e *joined) Unwrap() []error {
+	return e.errs
+}
+
+// joinFallback behaves like errors.Join: nil errors are dropped, and the
+// result is nil when none are left
+func joinFallback(errs []error) error {
+	var kept []error
+	for _, err := range errs {
+		if err != nil {
+			kept = append(kept, err)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return &joined{kept}
+}
@@ -0,0 +1,89 @@
+package compat
+
+import (
+	"errors"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	a, b := errors.New("a"), errors.New("b")
+	for name, join := range map[string]func(...error) error{
+		"Join":         Join,
+		"joinFallback": func(errs ...error) error { return joinFallback(errs) },
+	} {
+		if err := join(nil, nil); err != nil {
+			t.Errorf("%s(nil, nil) = %v, want nil", name, err)
+		}
+		err := join(a, nil, b)
+		if err == nil || err.Error() != "a\nb" {
+			t.Errorf("%s(a, nil, b) = %v, want a and b on their own lines", name, err)
+		}
+		if u, ok := err.(interface{ Unwrap() []error }); !ok || len(u.Unwrap()) != 2 {
+			t.Errorf("%s(a, nil, b) does not unwrap to both errors", name)
+		}
+	}
+}
+
+// The release tags of a toolchain at go1.N
+func releaseTags(n int) []string {
+	var tags []string
+	for i := 1; i <= n; i++ {
+		tags = append(tags, "go1."+itoa(i))
+	}
+	return tags
+}
+
+func itoa(i int) string {
+	if i < 10 {
+		return string(rune('0' + i))
+	}
+	return itoa(i/10) + string(rune('0'+i%10))
+}
+
+// Type-check the package in dir with the files a go1.N toolchain would
+// build and the language version it would accept, against the current
+// standard library
+func checkAs(t *testing.T, dir string, n int) {
+	ctx := build.Default
+	ctx.ReleaseTags = releaseTags(n)
+	bp, err := ctx.ImportDir(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+	}
+	setGoVersion(&conf, n)
+	if _, err := conf.Check(bp.ImportPath, fset, files, nil); err != nil {
+		t.Errorf("%s does not build as go1.%d: %v", dir, n, err)
+	}
+}
+
+// Both sides of every build tag must build, not only the one this
+// toolchain picks. The root package is only checked for the oldest tag
+// set, the toolchain running the tests builds it for the newest.
+func TestBuildTagSets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type-checks from source")
+	}
+	for _, n := range []int{16, 19, 20, 21} {
+		checkAs(t, ".", n)
+	}
+	checkAs(t, filepath.Join("..", ".."), 16)
+}
@@ -0,0 +1,11 @@
+//go:build go1.18
+// +build go1.18
+
+package compat
+
+import "go/types"
+
+// Hold the type-checker to the language of go1.N
+func setGoVersion(conf *types.Config, n int) {
+	conf.GoVersion = "go1." + itoa(n)
+}
@@ -0,0 +1,10 @@
+//go:build !go1.18
+// +build !go1.18
+
+package compat
+
+import "go/types"
+
+// types.Config has no GoVersion before go1.18, so the type-checker
+// accepts the language of the running toolchain
+func setGoVersion(conf *types.Config, n int) {}
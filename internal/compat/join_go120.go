@@ -0,0 +1,11 @@
+//go:build go1.20
+// +build go1.20
+
+package compat
+
+import "errors"
+
+// Join - errors.Join
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
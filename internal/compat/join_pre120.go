@@ -0,0 +1,9 @@
+//go:build !go1.20
+// +build !go1.20
+
+package compat
+
+// Join - errors.Join, which toolchains before go1.20 lack
+func Join(errs ...error) error {
+	return joinFallback(errs)
+}
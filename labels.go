@@ -0,0 +1,71 @@
+package gocron
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Limits on job labels, see Job.Label
+const (
+	MaxLabels      = 16
+	MaxLabelLength = 128
+)
+
+// Label - Attach a key=value label to the job, e.g.
+// s.Every(1).Hour().Label("team", "payments").Do(task)
+// Keys must be identifiers, [a-zA-Z_][a-zA-Z0-9_]*, so they can be used as
+// metric label names. An invalid label is reported by Do. Setting a label
+// bumps the job's version.
+func (j *Job) Label(key, value string) *Job {
+	if err := validLabel(key, value); err != nil {
+		j.setErr(err)
+		return j
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.labels[key]; !ok && len(j.labels) >= MaxLabels {
+		j.setErrLocked(errors.New("a job takes at most " + strconv.Itoa(MaxLabels) + " labels"))
+		return j
+	}
+	if j.labels == nil {
+		j.labels = make(map[string]string)
+	}
+	j.labels[key] = value
+	j.version++
+	return j
+}
+
+// Labels - A copy of the job's labels
+func (j *Job) Labels() map[string]string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.labelsCopy()
+}
+
+// labelsCopy copies the labels, j.mu must be held
+func (j *Job) labelsCopy() map[string]string {
+	if len(j.labels) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(j.labels))
+	for k, v := range j.labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+func validLabel(key, value string) error {
+	if key == "" || len(key) > MaxLabelLength || len(value) > MaxLabelLength {
+		return errors.New("label key and value must be 1 to " + strconv.Itoa(MaxLabelLength) + " bytes long")
+	}
+	for i, c := range key {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return errors.New("invalid label key " + strconv.Quote(key))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package gocron
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJob_Label(t *testing.T) {
+	job := NewScheduler().Every(1).Hour().Label("team", "payments").Label("cost_center", "123")
+	if err := job.Do(task); err != nil {
+		t.Fatal(err)
+	}
+	labels := job.Labels()
+	if len(labels) != 2 || labels["team"] != "payments" || labels["cost_center"] != "123" {
+		t.Errorf("Labels() = %v", labels)
+	}
+
+	labels["team"] = "changed"
+	if job.Labels()["team"] != "payments" {
+		t.Error("Labels() must return a copy")
+	}
+
+	version := job.Version()
+	job.Label("team", "billing")
+	if job.Version() != version+1 {
+		t.Error("changing a label should bump the version")
+	}
+}
+
+func TestJob_LabelInvalid(t *testing.T) {
+	tests := []struct {
+		name, key, value string
+	}{
+		{"empty key", "", "x"},
+		{"dash", "cost-center", "123"},
+		{"leading digit", "1team", "x"},
+		{"long value", "team", strings.Repeat("x", MaxLabelLength+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := NewScheduler().Every(1).Hour().Label(tt.key, tt.value)
+			if err := job.Do(task); err == nil {
+				t.Error("Do() should report the invalid label")
+			}
+		})
+	}
+
+	job := NewScheduler().Every(1).Hour()
+	for i := 0; i <= MaxLabels; i++ {
+		job.Label("l"+strings.Repeat("x", i), "v")
+	}
+	if err := job.Do(task); err == nil {
+		t.Errorf("Do() should reject more than %d labels", MaxLabels)
+	}
+}
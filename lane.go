@@ -0,0 +1,105 @@
+package gocron
+
+import "sync"
+
+// LanePolicy - What a lane does when it is full and another run is queued
+type LanePolicy int
+
+const (
+	// LaneBlock blocks the dispatcher until the lane has room again
+	LaneBlock LanePolicy = iota
+	// LaneSkipOldest drops the oldest queued run to make room for the new one
+	LaneSkipOldest
+)
+
+// DefaultLaneCapacity is the number of runs a lane queues before its policy applies
+const DefaultLaneCapacity = 16
+
+// lane is a FIFO of pending runs for one ordering key, drained by a
+// single worker so that runs sharing the key never overlap.
+type lane struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    []laneRun
+	capacity int
+	policy   LanePolicy
+	working  bool
+	// called by the worker once it ran out of runs, may be nil
+	onIdle func()
+
+	// the scheduler's key for the lane and the holds on it, guarded by
+	// the scheduler's laneMu, see laneFor
+	key  string
+	refs int
+}
+
+func newLane(capacity int, policy LanePolicy) *lane {
+	l := &lane{capacity: capacity, policy: policy}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// laneRun is a queued run, skip is called instead of call if it is dropped
+type laneRun struct {
+	call, skip func()
+}
+
+// push queues call, starting the worker if it is idle. skip, when not
+// nil, is called if the run is dropped by LaneSkipOldest, after the lane
+// is unlocked since it reaches the job's hooks.
+func (l *lane) push(call, skip func()) {
+	var dropped laneRun
+	l.mu.Lock()
+	for len(l.queue) >= l.capacity {
+		if l.policy == LaneSkipOldest {
+			dropped = l.queue[0]
+			l.queue = l.queue[1:]
+			break
+		}
+		l.cond.Wait()
+	}
+	l.queue = append(l.queue, laneRun{call, skip})
+	if !l.working {
+		l.working = true
+		go l.work()
+	}
+	l.mu.Unlock()
+	if dropped.skip != nil {
+		dropped.skip()
+	}
+}
+
+// work runs queued functions one at a time until the queue is empty
+func (l *lane) work() {
+	for {
+		l.mu.Lock()
+		if len(l.queue) == 0 {
+			l.working = false
+			l.mu.Unlock()
+			if l.onIdle != nil {
+				l.onIdle()
+			}
+			return
+		}
+		run := l.queue[0]
+		l.queue = l.queue[1:]
+		l.cond.Broadcast()
+		l.mu.Unlock()
+
+		run.call()
+	}
+}
+
+// Whether the lane has no run going or waiting
+func (l *lane) idle() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.working && len(l.queue) == 0
+}
+
+// depth returns the number of runs waiting in the lane, not counting the one executing
+func (l *lane) depth() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.queue)
+}
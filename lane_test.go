@@ -0,0 +1,242 @@
+package gocron
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type laneRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *laneRecorder) add(e string) {
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+func (r *laneRecorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func TestScheduler_OrderingKey(t *testing.T) {
+	scheduler := NewScheduler()
+	rec := &laneRecorder{}
+	otherStarted := make(chan struct{})
+	release := make(chan struct{})
+
+	applyStarted := make(chan struct{})
+	applyConfig := func() {
+		rec.add("apply start")
+		close(applyStarted)
+		<-release
+		rec.add("apply end")
+	}
+	report := func() {
+		rec.add("report start")
+		rec.add("report end")
+	}
+	other := func() {
+		close(otherStarted)
+	}
+
+	job1 := scheduler.Every(1).Second().OrderingKey("acme")
+	job1.Do(applyConfig)
+	job2 := scheduler.Every(1).Second().OrderingKey("acme")
+	job2.Do(report)
+	job3 := scheduler.Every(1).Second().OrderingKey("globex")
+	job3.Do(other)
+
+	now := time.Now()
+	job2.nextRun = now.Add(-1 * time.Second)
+	job1.nextRun = now.Add(-2 * time.Second)
+	job3.nextRun = now.Add(-2 * time.Second)
+	scheduler.RunPending()
+
+	select {
+	case <-otherStarted:
+	case <-time.After(time.Second):
+		t.Fatal("job with a different ordering key should not wait for the acme lane")
+	}
+	<-applyStarted
+	if d := scheduler.LaneDepth("acme"); d != 1 {
+		t.Errorf("LaneDepth(acme) = %d, want 1 while applyConfig runs", d)
+	}
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for len(rec.snapshot()) < 4 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	want := []string{"apply start", "apply end", "report start", "report end"}
+	got := rec.snapshot()
+	if len(got) != len(want) {
+		t.Fatalf("got events %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got events %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLane_SkipOldest(t *testing.T) {
+	l := newLane(1, LaneSkipOldest)
+	release := make(chan struct{})
+	done := make(chan string, 3)
+
+	l.push(func() { <-release; done <- "first" }, nil)
+	// wait for the worker to pick up the first run so the queue is empty
+	for l.depth() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	skipped := make(chan struct{}, 1)
+	l.push(func() { done <- "second" }, func() { skipped <- struct{}{} })
+	l.push(func() { done <- "third" }, nil)
+	if d := l.depth(); d != 1 {
+		t.Errorf("depth() = %d, want 1", d)
+	}
+	close(release)
+
+	if got := <-done; got != "first" {
+		t.Errorf("got %s, want first", got)
+	}
+	if got := <-done; got != "third" {
+		t.Errorf("got %s, want third after second was skipped", got)
+	}
+	select {
+	case <-skipped:
+	default:
+		t.Error("the dropped run should be told it was skipped")
+	}
+}
+
+func TestScheduler_LaneSkipOldestReleasesJob(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetLanePolicy(1, LaneSkipOldest)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	blocker := scheduler.Every(1).Hour().OrderingKey("acme")
+	blocker.Do(stuckJob, started, release)
+	ran := make(chan struct{}, 2)
+	queued := scheduler.Every(1).Hour().OrderingKey("acme").SingletonMode()
+	queued.Do(func() { ran <- struct{}{} })
+	other := scheduler.Every(1).Hour().OrderingKey("acme")
+	other.Do(task)
+
+	scheduler.runNow(blocker)
+	<-started
+	scheduler.runNow(queued)
+	scheduler.runNow(other) // drops queued's run
+	queued.mu.Lock()
+	running, count, skipped := queued.running, queued.runCount, queued.skippedRuns
+	queued.mu.Unlock()
+	if running || count != 0 || skipped != 1 {
+		t.Errorf("dropped run left running=%v, RunCount %d, SkippedRuns %d, want false, 0, 1", running, count, skipped)
+	}
+	close(release)
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	scheduler.runNow(queued)
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("the singleton job never ran again after its run was dropped")
+	}
+}
+
+func TestScheduler_LanesDeletedWhenIdle(t *testing.T) {
+	scheduler := NewScheduler()
+	for i := 0; i < 50; i++ {
+		scheduler.Every(1).Hour().OrderingKey("tenant-" + strconv.Itoa(i)).Do(task)
+	}
+	scheduler.RunAll()
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// the workers let go of their lanes right after the last run returns
+	deadline := time.Now().Add(time.Second)
+	for {
+		scheduler.laneMu.Lock()
+		n := len(scheduler.lanes)
+		scheduler.laneMu.Unlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d lanes left after their runs returned, want none", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// a key used again gets a fresh lane
+	ran := make(chan struct{})
+	again := scheduler.Every(1).Hour().OrderingKey("tenant-0")
+	again.Do(func() { close(ran) })
+	scheduler.runNow(again)
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("a run on a deleted lane's key never ran")
+	}
+}
+
+func TestLane_SkipOutsideLock(t *testing.T) {
+	l := newLane(1, LaneSkipOldest)
+	release := make(chan struct{})
+	defer close(release)
+	l.push(func() { <-release }, nil)
+	for l.depth() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// a skip reaching back into the lane, as the job's hooks may
+	l.push(func() {}, func() { l.depth() })
+	pushed := make(chan struct{})
+	go func() {
+		l.push(func() {}, nil)
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Fatal("push deadlocked calling skip with the lane locked")
+	}
+}
+
+func TestScheduler_LaneHeldUntilTimedOutCallReturns(t *testing.T) {
+	scheduler := NewScheduler()
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	timedOut := make(chan struct{})
+	slow := scheduler.Every(1).Hour().OrderingKey("acme").Timeout(20 * time.Millisecond)
+	slow.OnError(func(err error) {
+		if err == ErrTimeout {
+			close(timedOut)
+		}
+	})
+	slow.Do(stuckJob, started, release)
+	next := make(chan struct{})
+	follower := scheduler.Every(1).Hour().OrderingKey("acme")
+	follower.Do(func() { close(next) })
+
+	scheduler.runNow(slow)
+	<-started
+	scheduler.runNow(follower)
+	<-timedOut
+	select {
+	case <-next:
+		t.Fatal("the next run on the lane started while the timed out call was still going")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-next:
+	case <-time.After(time.Second):
+		t.Fatal("the next run on the lane never started after the call returned")
+	}
+}
@@ -0,0 +1,50 @@
+package gocron
+
+import "errors"
+
+// LimitRunsTo - Remove the job from its scheduler after it has run n
+// times, e.g. s.Every(30).Seconds().LimitRunsTo(5).Do(task)
+func (j *Job) LimitRunsTo(n int) *Job {
+	if !j.building("LimitRunsTo") {
+		return j
+	}
+	if n < 1 {
+		j.setErr(errors.New("LimitRunsTo() requires a positive limit"))
+		return j
+	}
+	j.mu.Lock()
+	j.limit = n
+	j.mu.Unlock()
+	return j
+}
+
+// Once - Run the job a single time, when it first comes due, e.g.
+// s.Every(30).Seconds().Once().Do(task) runs task 30 seconds from now
+func (j *Job) Once() *Job {
+	return j.LimitRunsTo(1)
+}
+
+// RunCount - How many times the job has run. A singleton run queued
+// behind the one going counts as soon as it is queued.
+func (j *Job) RunCount() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.runCount
+}
+
+// Count a run, removing the job when it reached its limit. Requires j.mu held.
+func (j *Job) countRun() {
+	j.runCount++
+	if j.limit > 0 && j.runCount >= j.limit {
+		j.removed = true
+	}
+}
+
+// Take back a run counted by countRun that did not start. Requires j.mu held.
+func (j *Job) uncountRun() {
+	j.runCount--
+	// the run counted was the one reaching the limit
+	if j.limit > 0 && j.runCount == j.limit-1 {
+		j.removed = false
+	}
+}
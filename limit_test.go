@@ -0,0 +1,61 @@
+package gocron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJob_LimitRunsTo(t *testing.T) {
+	scheduler := NewScheduler()
+	var runs, others int32
+	job := scheduler.Every(1).Hour().LimitRunsTo(3)
+	job.Do(func() { atomic.AddInt32(&runs, 1) })
+	other := scheduler.Every(1).Hour()
+	other.Do(func() { atomic.AddInt32(&others, 1) })
+
+	for i := 0; i < 5; i++ {
+		scheduler.RunAll()
+	}
+	scheduler.Wait(time.Second)
+	if n := atomic.LoadInt32(&runs); n != 3 || job.RunCount() != 3 {
+		t.Errorf("got %d runs, RunCount() = %d; want 3", n, job.RunCount())
+	}
+	if n := atomic.LoadInt32(&others); n != 5 || other.RunCount() != 5 {
+		t.Errorf("the other job ran %d times, want 5", n)
+	}
+	if jobs := scheduler.Jobs(); len(jobs) != 1 || jobs[0] != other {
+		t.Errorf("got jobs %v, want only the unlimited one left", jobs)
+	}
+	if _, ok := job.TimeUntilNextRun(); ok {
+		t.Error("a job past its limit should not be scheduled")
+	}
+
+	if err := scheduler.Every(1).Hour().LimitRunsTo(0).Do(func() {}); err == nil {
+		t.Error("LimitRunsTo(0) should fail")
+	}
+}
+
+func TestJob_Once(t *testing.T) {
+	scheduler := NewScheduler()
+	var runs int32
+	job := scheduler.Every(30).Seconds().Once()
+	job.Do(func() { atomic.AddInt32(&runs, 1) })
+	if d, _ := job.TimeUntilNextRun(); d < 29*time.Second {
+		t.Errorf("first run in %v, want 30 seconds from now", d)
+	}
+
+	for i := 0; i < 2; i++ {
+		job.mu.Lock()
+		job.nextRun = time.Now().Add(-time.Second)
+		job.mu.Unlock()
+		scheduler.RunPending()
+	}
+	scheduler.Wait(time.Second)
+	if n := atomic.LoadInt32(&runs); n != 1 {
+		t.Errorf("got %d runs, want 1", n)
+	}
+	if len(scheduler.Jobs()) != 0 {
+		t.Error("the job is still scheduled after its one run")
+	}
+}
@@ -0,0 +1,38 @@
+package gocron
+
+import (
+	"log"
+	"os"
+)
+
+// Logger - Where the scheduler reports warnings, satisfied by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+var defaultLogger Logger = log.New(os.Stderr, "gocron: ", log.LstdFlags)
+
+// SetLogger - Set the logger for the scheduler's warnings, nil silences them
+func (s *Scheduler) SetLogger(l Logger) {
+	s.mu.Lock()
+	s.logger = l
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) logf(format string, v ...interface{}) {
+	s.mu.RLock()
+	l := s.logger
+	s.mu.RUnlock()
+	if l != nil {
+		l.Printf(format, v...)
+	}
+}
+
+// Log to the job's scheduler, or to the package logger for a job that has none
+func (j *Job) logf(format string, v ...interface{}) {
+	if j.scheduler != nil {
+		j.scheduler.logf(format, v...)
+		return
+	}
+	defaultLogger.Printf(format, v...)
+}
@@ -0,0 +1,23 @@
+//go:build go1.21
+// +build go1.21
+
+package gocron
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// SlogLogger - A Logger writing the scheduler's warnings to l at warn
+// level, e.g. s.SetLogger(gocron.SlogLogger(slog.Default()))
+func SlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l}
+}
+
+func (s slogLogger) Printf(format string, v ...interface{}) {
+	s.l.Warn(fmt.Sprintf(format, v...))
+}
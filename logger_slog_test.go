@@ -0,0 +1,21 @@
+//go:build go1.21
+// +build go1.21
+
+package gocron
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	scheduler := NewScheduler()
+	scheduler.SetLogger(SlogLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	scheduler.logf("job %s: %v", "sync", "late")
+	if got := buf.String(); !strings.Contains(got, "level=WARN") || !strings.Contains(got, `msg="job sync: late"`) {
+		t.Errorf("logged %q", got)
+	}
+}
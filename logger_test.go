@@ -0,0 +1,18 @@
+package gocron
+
+import (
+	"fmt"
+	"sync"
+)
+
+// A Logger keeping what it was given, for tests to check
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+	l.mu.Unlock()
+}
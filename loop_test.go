@@ -0,0 +1,151 @@
+package gocron
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler_StartSleepsUntilDue(t *testing.T) {
+	scheduler := NewScheduler()
+	for i := 0; i < 1000; i++ {
+		scheduler.Every(1).Hour().Do(func() {})
+	}
+	stopped := scheduler.Start()
+	defer func() { stopped <- true }()
+
+	time.Sleep(1500 * time.Millisecond)
+	if passes := scheduler.RecentDispatchPasses(DispatchLogSize); len(passes) != 0 {
+		t.Errorf("got %d dispatch passes with nothing due, want none", len(passes))
+	}
+
+	// adding a job wakes the loop up
+	ran := make(chan struct{}, 1)
+	scheduler.EveryDuration(50 * time.Millisecond).Do(func() {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+	})
+	select {
+	case <-ran:
+	case <-time.After(500 * time.Millisecond):
+		t.Error("job added while the loop sleeps did not run")
+	}
+}
+
+func BenchmarkScheduler_untilNextWake(b *testing.B) {
+	scheduler := NewScheduler()
+	for i := 0; i < 10000; i++ {
+		scheduler.Every(1).Hour().Do(func() {})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scheduler.untilNextWake()
+	}
+}
+
+func TestScheduler_SetDispatchLookahead(t *testing.T) {
+	var mu sync.Mutex
+	runs := 0
+	count := func() {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+	}
+	ran := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return runs
+	}
+	// a job due 500ms after a tick, at a 1s resolution
+	tick := func(s *Scheduler) (*Job, time.Time) {
+		job := s.Every(10).Seconds()
+		job.Do(count)
+		due := time.Now().Add(500 * time.Millisecond)
+		job.mu.Lock()
+		job.nextRun = due
+		job.mu.Unlock()
+		s.RunPending()
+		s.Wait(time.Second)
+		return job, due
+	}
+
+	if tick(NewScheduler()); ran() != 0 {
+		t.Fatal("a job ran before it was due without a lookahead")
+	}
+
+	scheduler := NewScheduler()
+	if err := scheduler.SetDispatchLookahead(-time.Second); err == nil {
+		t.Error("a negative lookahead should be rejected")
+	}
+	scheduler.SetDispatchLookahead(900 * time.Millisecond)
+	job, due := tick(scheduler)
+	if ran() != 1 {
+		t.Fatalf("got %d runs, want the job run a tick early", ran())
+	}
+	job.mu.Lock()
+	lastRun, nextRun := job.lastRun, job.nextRun
+	job.mu.Unlock()
+	if !lastRun.Equal(due) || !nextRun.Equal(due.Add(10*time.Second)) {
+		t.Errorf("last run %s, next %s; want the run recorded at %s", lastRun, nextRun, due)
+	}
+
+	// the next tick must not run the same occurrence again
+	scheduler.RunPending()
+	scheduler.Wait(time.Second)
+	if ran() != 1 {
+		t.Errorf("got %d runs, want the occurrence run exactly once", ran())
+	}
+}
+
+func TestScheduler_ConcurrentUse(t *testing.T) {
+	scheduler := NewScheduler()
+	stopped := scheduler.Start()
+	defer func() { stopped <- true }()
+
+	noop := func() {}
+	removable := func() {}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				scheduler.Every(1).Second().Do(noop)
+				scheduler.NextRun()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			scheduler.Every(1).Second().Do(removable)
+			scheduler.RemoveFirstByFunction(removable)
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			sort.Sort(scheduler)
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		deadline := time.Now().Add(1500 * time.Millisecond)
+		for time.Now().Before(deadline) {
+			scheduler.RunPending()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	wg.Wait()
+
+	scheduler.Clear()
+	if job, _ := scheduler.NextRun(); job != nil {
+		t.Error("Clear should remove every job")
+	}
+}
@@ -0,0 +1,145 @@
+package gocron
+
+import (
+	"sync"
+	"time"
+)
+
+// JobStatus - How a run of a job ended, see Monitor
+type JobStatus int
+
+const (
+	// JobSuccess is a run that returned without an error
+	JobSuccess JobStatus = iota
+	// JobFail is a run that returned an error, after its retries, or timed out
+	JobFail
+	// JobSkipped is a run that was dropped, as counted by SkippedRuns
+	JobSkipped
+	// JobPanicked is a run whose function panicked
+	JobPanicked
+	// JobShadow is a run a scheduler in shadow mode recorded instead of
+	// calling the function, see SetShadowMode
+	JobShadow
+)
+
+func (st JobStatus) String() string {
+	switch st {
+	case JobFail:
+		return "fail"
+	case JobSkipped:
+		return "skipped"
+	case JobPanicked:
+		return "panicked"
+	case JobShadow:
+		return "shadow"
+	}
+	return "success"
+}
+
+// Monitor - Told about every run of a scheduler's jobs, e.g. to export
+// them as Prometheus counters. name is the job's GetName, so give jobs
+// running closures a Name. Methods are called on the runs' goroutines
+// and must be safe for concurrent use.
+type Monitor interface {
+	// a run ended with status
+	IncrementJob(name string, status JobStatus)
+	// a run that started, whatever its status, called before IncrementJob;
+	// times are on the wall clock, whatever the scheduler's clock
+	RecordJobTiming(name string, start, end time.Time)
+}
+
+// SetMonitor - Report the runs of the scheduler's jobs to m, nil stops reporting
+func (s *Scheduler) SetMonitor(m Monitor) {
+	s.mu.Lock()
+	s.monitor = m
+	s.mu.Unlock()
+}
+
+// The scheduler's monitor and the job's name for it, neither s.mu nor
+// j.mu may be held
+func (j *Job) monitor() (Monitor, string) {
+	j.mu.Lock()
+	s, name := j.scheduler, j.displayName()
+	j.mu.Unlock()
+	if s == nil {
+		return nil, ""
+	}
+	s.mu.RLock()
+	m := s.monitor
+	s.mu.RUnlock()
+	return m, name
+}
+
+// Tell the monitor about a run that ended with status
+func (j *Job) report(status JobStatus) {
+	if m, name := j.monitor(); m != nil {
+		m.IncrementJob(name, status)
+	}
+}
+
+// JobStats - The runs a MemoryMonitor saw of the jobs with one name
+type JobStats struct {
+	Success, Fail, Skipped, Panicked, Shadow uint64
+	// the latest run that started, zero before one returns
+	LastStart    time.Time
+	LastDuration time.Duration
+}
+
+// MemoryMonitor - A Monitor keeping JobStats in memory, the zero value is
+// ready to use
+type MemoryMonitor struct {
+	mu   sync.Mutex
+	jobs map[string]JobStats
+}
+
+// NewMemoryMonitor - Create an empty MemoryMonitor
+func NewMemoryMonitor() *MemoryMonitor {
+	return &MemoryMonitor{}
+}
+
+// IncrementJob implements Monitor
+func (m *MemoryMonitor) IncrementJob(name string, status JobStatus) {
+	m.update(name, func(st *JobStats) {
+		switch status {
+		case JobSuccess:
+			st.Success++
+		case JobFail:
+			st.Fail++
+		case JobSkipped:
+			st.Skipped++
+		case JobPanicked:
+			st.Panicked++
+		case JobShadow:
+			st.Shadow++
+		}
+	})
+}
+
+// RecordJobTiming implements Monitor
+func (m *MemoryMonitor) RecordJobTiming(name string, start, end time.Time) {
+	m.update(name, func(st *JobStats) {
+		st.LastStart, st.LastDuration = start, end.Sub(start)
+	})
+}
+
+func (m *MemoryMonitor) update(name string, fn func(*JobStats)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.jobs == nil {
+		m.jobs = make(map[string]JobStats)
+	}
+	st := m.jobs[name]
+	fn(&st)
+	m.jobs[name] = st
+}
+
+// Stats - A copy of the stats so far by job name
+func (m *MemoryMonitor) Stats() map[string]JobStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := make(map[string]JobStats, len(m.jobs))
+	for name, st := range m.jobs {
+		stats[name] = st
+	}
+	return stats
+}
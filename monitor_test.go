@@ -0,0 +1,73 @@
+package gocron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestScheduler_SetMonitor(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	monitor := NewMemoryMonitor()
+	scheduler.SetMonitor(monitor)
+
+	release := make(chan struct{})
+	scheduler.Every(1).Second().Name("ok").Do(func() {})
+	scheduler.Every(1).Second().Name("fail").Do(func() error { return errors.New("x") })
+	scheduler.Every(1).Second().Name("panic").Do(panickingJob)
+	scheduler.Every(1).Second().Name("slow").SingletonMode().Do(func() { <-release })
+
+	// the slow job's first run is still going on the later passes
+	for i := 0; i < 3; i++ {
+		fake.Advance(1500 * time.Millisecond)
+		scheduler.RunPending()
+	}
+	close(release)
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := monitor.Stats()
+	for name, want := range map[string]JobStats{
+		"ok":    {Success: 3},
+		"fail":  {Fail: 3},
+		"panic": {Panicked: 3},
+		"slow":  {Success: 1, Skipped: 2},
+	} {
+		got := stats[name]
+		if got.Success != want.Success || got.Fail != want.Fail || got.Skipped != want.Skipped || got.Panicked != want.Panicked {
+			t.Errorf("%s: got %+v, want %+v", name, got, want)
+		}
+		if got.LastStart.IsZero() {
+			t.Errorf("%s: no run timing was recorded", name)
+		}
+	}
+	if d := stats["slow"].LastDuration; d <= 0 {
+		t.Errorf("slow: last duration %v, want the time it was blocked", d)
+	}
+
+	scheduler.SetMonitor(nil)
+	fake.Advance(1500 * time.Millisecond)
+	scheduler.RunPendingAndWait()
+	if got := monitor.Stats()["ok"].Success; got != 3 {
+		t.Errorf("ok: %d successes after SetMonitor(nil), want 3", got)
+	}
+}
+
+func TestJobStatus_String(t *testing.T) {
+	for status, want := range map[JobStatus]string{
+		JobSuccess:  "success",
+		JobFail:     "fail",
+		JobSkipped:  "skipped",
+		JobPanicked: "panicked",
+		JobShadow:   "shadow",
+	} {
+		if got := status.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package gocron
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Month - Set the unit with months, s.Every(1).Month().DayOfTheMonth(1).At("02:00").Do(task)
+func (j *Job) Month() (job *Job) {
+	if !j.building("Month") {
+		return j
+	}
+	if j.interval != 1 {
+		j.setErr(errors.New("Month() requires an interval of 1"))
+		return j
+	}
+	job = j.Months()
+	return
+}
+
+// Months - Set the job's unit with months. Runs are interval calendar
+// months apart, at the At time or midnight, on the day set with
+// DayOfTheMonth or else the day Do is called.
+func (j *Job) Months() *Job {
+	if !j.building("Months") {
+		return j
+	}
+	j.setUnit("Months", UnitMonths)
+	return j
+}
+
+// DayOfTheMonth - Run a monthly job on day, 1 to 31. In months without that
+// day, e.g. the 31st in April or the 29th in February of a common year,
+// the job runs on the last day of the month instead.
+func (j *Job) DayOfTheMonth(day int) *Job {
+	if !j.building("DayOfTheMonth") {
+		return j
+	}
+	if day < 1 || day > 31 {
+		j.setErr(errors.New("DayOfTheMonth() requires a day from 1 to 31, got " + strconv.Itoa(day)))
+		return j
+	}
+	j.dayOfMonth = day
+	return j
+}
+
+// FirstWeekdayOfTheMonth - Run the job monthly on the first day of the
+// month falling on day, e.g. s.Every(1).FirstWeekdayOfTheMonth(time.Monday).At("09:00")
+func (j *Job) FirstWeekdayOfTheMonth(day time.Weekday) *Job {
+	return j.weekdayOfMonth("FirstWeekdayOfTheMonth", day, 1)
+}
+
+// LastWeekdayOfTheMonth - Run the job monthly on the last day of the
+// month falling on day, e.g. s.Every(1).LastWeekdayOfTheMonth(time.Friday)
+func (j *Job) LastWeekdayOfTheMonth(day time.Weekday) *Job {
+	return j.weekdayOfMonth("LastWeekdayOfTheMonth", day, -1)
+}
+
+func (j *Job) weekdayOfMonth(name string, day time.Weekday, week int) *Job {
+	if !j.building(name) {
+		return j
+	}
+	if day < time.Sunday || day > time.Saturday {
+		j.setErr(errors.New(name + "() takes a day from Sunday to Saturday"))
+		return j
+	}
+	if j.monthWeek != 0 && (j.monthWeek != week || j.monthWeekday != day) {
+		j.setErr(errors.New(name + "() called on a job that already runs on another weekday of the month"))
+		return j
+	}
+	j.setUnit(name, UnitMonths)
+	j.monthWeek = week
+	j.monthWeekday = day
+	return j
+}
+
+// Reject DayOfTheMonth on a job that does not run monthly, or that runs on
+// a weekday of the month, checked by Do since the unit may be set after it
+func (j *Job) checkDayOfMonth() error {
+	if j.dayOfMonth > 0 && j.unit != UnitMonths {
+		return errors.New("DayOfTheMonth() requires a monthly job, this one runs in " + j.unit)
+	}
+	if j.dayOfMonth > 0 && j.monthWeek != 0 {
+		return errors.New("DayOfTheMonth() cannot be combined with FirstWeekdayOfTheMonth() or LastWeekdayOfTheMonth()")
+	}
+	return nil
+}
+
+// Compute nextRun of a monthly job, from the month of the last run or,
+// before the first one, as the first slot after now
+func (j *Job) scheduleMonthly() {
+	loc := j.location()
+	if j.dayOfMonth == 0 && j.monthWeek == 0 {
+		j.dayOfMonth = j.now().In(loc).Day()
+	}
+	if j.lastRun == time.Unix(0, 0) {
+		now := j.now().In(loc)
+		next := j.monthSlot(now.Year(), now.Month(), loc)
+		if !next.After(now) {
+			if later, ok := j.laterAt(now); ok && later.Day() == next.Day() {
+				next = later
+			} else {
+				next = j.monthSlot(now.Year(), now.Month()+1, loc)
+			}
+		}
+		j.nextRun = next
+		return
+	}
+	last := j.lastRun.In(loc)
+	if slot := j.monthSlot(last.Year(), last.Month(), loc); slot.Day() == last.Day() {
+		// the next of several At times on the same day
+		if later, ok := j.laterAt(last); ok {
+			j.nextRun = later
+			return
+		}
+	}
+	j.nextRun = j.monthSlot(last.Year(), last.Month()+time.Month(j.interval), loc)
+}
+
+// The job's run in month of year, which may be past December, with the
+// day clamped to the month's length
+func (j *Job) monthSlot(year int, month time.Month, loc *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	day := j.dayOfMonth
+	n := daysIn(first.Year(), first.Month())
+	switch {
+	case j.monthWeek > 0:
+		day = 1 + int(j.monthWeekday-first.Weekday()+7)%7
+	case j.monthWeek < 0:
+		last := time.Date(first.Year(), first.Month(), n, 0, 0, 0, 0, loc)
+		day = n - int(last.Weekday()-j.monthWeekday+7)%7
+	case day > n:
+		day = n
+	}
+	at := timeOfDay{}
+	if len(j.ats) > 0 {
+		at = j.ats[0]
+	}
+	return at.on(time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, loc))
+}
+
+// The number of days in month of year
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,182 @@
+package gocron
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestJob_Months(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 2, 0, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		interval uint64
+		day      int
+		last     time.Time
+		want     time.Time
+	}{
+		{1, 1, date(2023, time.January, 1), date(2023, time.February, 1)},
+		{1, 31, date(2023, time.January, 31), date(2023, time.February, 28)},
+		{1, 31, date(2023, time.February, 28), date(2023, time.March, 31)},
+		{1, 31, date(2024, time.January, 31), date(2024, time.February, 29)},
+		{1, 29, date(2100, time.January, 29), date(2100, time.February, 28)},
+		{1, 30, date(2000, time.January, 30), date(2000, time.February, 29)},
+		{1, 15, date(2023, time.December, 15), date(2024, time.January, 15)},
+		{3, 30, date(2023, time.November, 30), date(2024, time.February, 29)},
+		{3, 31, date(2023, time.October, 31), date(2024, time.January, 31)},
+		{12, 29, date(2024, time.February, 29), date(2025, time.February, 28)},
+	}
+	scheduler := NewScheduler()
+	for _, c := range cases {
+		job := scheduler.Every(c.interval).Months().DayOfTheMonth(c.day).At("02:00").Loc(time.UTC)
+		if err := job.Do(func() {}); err != nil {
+			t.Fatal(err)
+		}
+		job.mu.Lock()
+		job.lastRun = c.last
+		job.scheduleNextRun()
+		got := job.nextRun
+		job.mu.Unlock()
+		if !got.Equal(c.want) {
+			t.Errorf("every %d months on day %d after %v: next run %v, want %v", c.interval, c.day, c.last, got, c.want)
+		}
+	}
+}
+
+func TestJob_MonthsFirstRun(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Month().DayOfTheMonth(1).At("02:00")
+	if err := job.Do(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	next := job.NextScheduledTime().In(time.Local)
+	now := time.Now()
+	if !next.After(now) || next.After(now.AddDate(0, 1, 1)) {
+		t.Errorf("first run %v, want the next 1st of a month", next)
+	}
+	if next.Day() != 1 || next.Hour() != 2 || next.Minute() != 0 {
+		t.Errorf("first run %v, want 02:00 on the 1st", next)
+	}
+	if got, want := job.String(), "every month on day 1 at 02:00, next "; !strings.Contains(got, want) {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestJob_MonthsErrors(t *testing.T) {
+	scheduler := NewScheduler()
+	if err := scheduler.Every(2).Month().Do(func() {}); err == nil {
+		t.Error("Every(2).Month() should fail")
+	}
+	for _, day := range []int{0, 32, -1} {
+		if err := scheduler.Every(1).Month().DayOfTheMonth(day).Do(func() {}); err == nil {
+			t.Errorf("DayOfTheMonth(%d) should fail", day)
+		}
+	}
+	for _, job := range []*Job{scheduler.Every(1).Day().DayOfTheMonth(5), scheduler.Every(1).DayOfTheMonth(5)} {
+		if err := job.Do(func() {}); err == nil || !strings.Contains(err.Error(), "requires a monthly job") {
+			t.Errorf("Do() = %v, want DayOfTheMonth on a job that is not monthly rejected", err)
+		}
+	}
+	if err := scheduler.Every(1).DayOfTheMonth(5).Months().Do(func() {}); err != nil {
+		t.Errorf("DayOfTheMonth before Months: %v", err)
+	}
+	if err := scheduler.Every(1).Hour().Reschedule(2, UnitMonths); err != nil {
+		t.Errorf("Reschedule to months: %v", err)
+	}
+}
+
+// The runs of job over the months after now, on a fake clock
+func monthlyRuns(t *testing.T, now time.Time, months int, build func(*Scheduler) *Job) []time.Time {
+	t.Helper()
+	scheduler := NewScheduler()
+	scheduler.ChangeLoc(time.UTC)
+	fake := clock.NewFake(now)
+	scheduler.SetClock(fake)
+	if err := build(scheduler).Do(task); err != nil {
+		t.Fatal(err)
+	}
+	report, err := scheduler.FastForward(context.Background(), now.AddDate(0, months, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var runs []time.Time
+	for _, run := range report.Runs {
+		// FastForward dispatches just after the due time
+		runs = append(runs, run.At.Truncate(time.Second))
+	}
+	return runs
+}
+
+func TestJob_WeekdayOfTheMonth(t *testing.T) {
+	date := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, time.UTC) }
+	firstMonday := func(s *Scheduler) *Job { return s.Every(1).FirstWeekdayOfTheMonth(time.Monday).At("09:00") }
+	lastFriday := func(s *Scheduler) *Job { return s.Every(1).Month().LastWeekdayOfTheMonth(time.Friday) }
+	cases := []struct {
+		name   string
+		now    time.Time
+		months int
+		build  func(*Scheduler) *Job
+		want   []time.Time
+	}{
+		{"first monday", date(2024, 5, 1, 12), 3, firstMonday,
+			[]time.Time{date(2024, 5, 6, 9), date(2024, 6, 3, 9), date(2024, 7, 1, 9)}},
+		{"first monday already passed", date(2024, 5, 7, 12), 1, firstMonday,
+			[]time.Time{date(2024, 6, 3, 9)}},
+		{"first monday later today", date(2024, 5, 6, 8), 1, firstMonday,
+			[]time.Time{date(2024, 5, 6, 9), date(2024, 6, 3, 9)}},
+		{"first monday over new year", date(2024, 12, 10, 0), 1, firstMonday,
+			[]time.Time{date(2025, 1, 6, 9)}},
+		// the 31st, 28th, 26th and 30th
+		{"last friday", date(2024, 5, 1, 12), 4, lastFriday,
+			[]time.Time{date(2024, 5, 31, 0), date(2024, 6, 28, 0), date(2024, 7, 26, 0), date(2024, 8, 30, 0)}},
+		{"last friday on the 29th", date(2024, 3, 1, 0), 1, lastFriday,
+			[]time.Time{date(2024, 3, 29, 0)}},
+		{"last friday over new year", date(2024, 12, 28, 0), 2, lastFriday,
+			[]time.Time{date(2025, 1, 31, 0), date(2025, 2, 28, 0)}},
+		{"every 2 months", date(2024, 5, 1, 12), 5, func(s *Scheduler) *Job { return s.Every(2).LastWeekdayOfTheMonth(time.Sunday) },
+			[]time.Time{date(2024, 5, 26, 0), date(2024, 7, 28, 0), date(2024, 9, 29, 0)}},
+	}
+	for _, c := range cases {
+		runs := monthlyRuns(t, c.now, c.months, c.build)
+		if len(runs) != len(c.want) {
+			t.Errorf("%s: runs %v, want %v", c.name, runs, c.want)
+			continue
+		}
+		for i := range runs {
+			if !runs[i].Equal(c.want[i]) {
+				t.Errorf("%s: run %d at %v, want %v", c.name, i, runs[i], c.want[i])
+			}
+		}
+	}
+
+	s := NewScheduler()
+	if got, want := schedule(firstMonday(s)), "every month on the first monday at 09:00"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := schedule(lastFriday(s)), "every month on the last friday"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestJob_WeekdayOfTheMonthErrors(t *testing.T) {
+	s := NewScheduler()
+	cases := map[string]*Job{
+		"with DayOfTheMonth": s.Every(1).Month().DayOfTheMonth(3).FirstWeekdayOfTheMonth(time.Monday),
+		"first and last":     s.Every(1).FirstWeekdayOfTheMonth(time.Monday).LastWeekdayOfTheMonth(time.Monday),
+		"not a weekday":      s.Every(1).LastWeekdayOfTheMonth(time.Weekday(7)),
+		"on a weekly job":    s.Every(1).Monday().FirstWeekdayOfTheMonth(time.Monday),
+		"two days":           s.Every(1).FirstWeekdayOfTheMonth(time.Monday).FirstWeekdayOfTheMonth(time.Friday),
+	}
+	for name, job := range cases {
+		if err := job.Do(task); err == nil {
+			t.Errorf("%s: Do() should fail", name)
+		}
+	}
+	if err := s.Every(1).FirstWeekdayOfTheMonth(time.Monday).FirstWeekdayOfTheMonth(time.Monday).Do(task); err != nil {
+		t.Errorf("the same weekday twice failed: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package gocron
+
+// Name - Give the job a human-readable name, shown by String instead of
+// the function name, which is all "func1" for closures
+func (j *Job) Name(name string) *Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.name = name
+	j.version++
+	return j
+}
+
+// GetName - The job's name, the short function name if Name was not
+// called, "" before Do
+func (j *Job) GetName() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.displayName()
+}
+
+// requires j.mu held
+func (j *Job) displayName() string {
+	if j.name != "" {
+		return j.name
+	}
+	if j.jobFunc == "" {
+		return ""
+	}
+	return shortFuncName(j.jobFunc)
+}
@@ -0,0 +1,61 @@
+package gocron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJob_Name(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Day().At("02:00").Loc(time.UTC)
+	if got := job.GetName(); got != "" {
+		t.Errorf("GetName() = %q before Do, want empty", got)
+	}
+	job.Do(func() {})
+	if got := job.GetName(); got != "gocron.TestJob_Name.func1" {
+		t.Errorf("GetName() = %q, want the function name", got)
+	}
+
+	version := job.Version()
+	job.Name("billing-sync")
+	if job.Version() == version {
+		t.Error("Name() did not bump the version")
+	}
+	if got := job.GetName(); got != "billing-sync" {
+		t.Errorf("GetName() = %q, want billing-sync", got)
+	}
+	next := job.NextRun().UTC()
+	want := "[billing-sync] every day at 02:00, next " + next.Format(time.RFC3339)
+	if got := job.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if next.Hour() != 2 || !strings.HasSuffix(want, "T02:00:00Z") {
+		t.Errorf("next run %v, want 02:00 UTC", next)
+	}
+
+	job.RemoveSelf()
+	if got := job.String(); got != "[billing-sync] every day at 02:00" {
+		t.Errorf("String() = %q after RemoveSelf, want no next run", got)
+	}
+}
+
+func TestScheduler_JobsOrderedByNextRun(t *testing.T) {
+	scheduler := NewScheduler()
+	hourly := scheduler.Every(1).Hour()
+	hourly.Do(task)
+	minutely := scheduler.Every(1).Minute()
+	minutely.Do(task)
+	daily := scheduler.Every(1).Day()
+	daily.Do(task)
+
+	ordered := scheduler.JobsOrderedByNextRun()
+	if len(ordered) != 3 || ordered[0] != minutely || ordered[1] != hourly || ordered[2] != daily {
+		t.Errorf("JobsOrderedByNextRun() = %v, want minutely, hourly, daily", ordered)
+	}
+	scheduler.RunPending()
+	scheduler.NextRun()
+	if jobs := scheduler.Jobs(); jobs[0] != hourly || jobs[1] != minutely || jobs[2] != daily {
+		t.Errorf("Jobs() = %v, want the order they were added in", jobs)
+	}
+}
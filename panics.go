@@ -0,0 +1,71 @@
+package gocron
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SetPanicHandler - Call fn with the recovered value and stack whenever a
+// job's function panics. The panic is logged when no handler is set.
+// Either way the scheduler keeps going and the job stays scheduled, see
+// DisableAfterPanics.
+func (s *Scheduler) SetPanicHandler(fn func(job *Job, recovered interface{}, stack []byte)) {
+	s.mu.Lock()
+	s.panicHandler = fn
+	s.mu.Unlock()
+}
+
+// DisableAfterPanics - Pause the job once n runs in a row have panicked,
+// e.g. s.Every(1).Minute().DisableAfterPanics(3).Do(task). Resume
+// enables it again.
+func (j *Job) DisableAfterPanics(n int) *Job {
+	if !j.building("DisableAfterPanics") {
+		return j
+	}
+	if n < 1 {
+		j.setErr(errors.New("DisableAfterPanics() requires a positive count"))
+		return j
+	}
+	j.mu.Lock()
+	j.panicLimit = n
+	j.mu.Unlock()
+	return j
+}
+
+// Panics - How many runs in a row have panicked
+func (j *Job) Panics() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.panics
+}
+
+// Pass the result of a run that panicked to the panic handler, returning
+// the panic as the run's error
+func (j *Job) handlePanic(r runResult) error {
+	j.mu.Lock()
+	j.panics++
+	disable := j.panicLimit > 0 && j.panics >= j.panicLimit && !j.paused
+	if disable {
+		j.paused = true
+		j.pauseMode = PauseRecomputeOnResume
+		j.version++
+	}
+	name, panics := j.jobFunc, j.panics
+	j.mu.Unlock()
+
+	var handler func(*Job, interface{}, []byte)
+	if s := j.scheduler; s != nil {
+		s.mu.RLock()
+		handler = s.panicHandler
+		s.mu.RUnlock()
+	}
+	if handler != nil {
+		handler(j, r.recovered, r.stack)
+	} else {
+		j.logf("job %s panicked: %v\n%s", name, r.recovered, r.stack)
+	}
+	if disable {
+		j.logf("job %s paused after %d panics in a row", name, panics)
+	}
+	return errors.New("panic: " + fmt.Sprint(r.recovered))
+}
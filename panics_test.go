@@ -0,0 +1,110 @@
+package gocron
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func panickingJob() {
+	panic("x")
+}
+
+func TestScheduler_PanicHandler(t *testing.T) {
+	scheduler := NewScheduler()
+	var mu sync.Mutex
+	var recovered []interface{}
+	var stack []byte
+	scheduler.SetPanicHandler(func(job *Job, r interface{}, s []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		recovered = append(recovered, r)
+		stack = s
+	})
+	var failures []error
+	job := scheduler.Every(1).Second().OnError(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures = append(failures, err)
+	})
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	job.Do(panickingJob)
+
+	for i := 0; i < 2; i++ {
+		fake.Advance(1500 * time.Millisecond)
+		scheduler.RunPendingAndWait()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(recovered) != 2 || recovered[0] != "x" {
+		t.Fatalf("handler got %v, want a panic per run", recovered)
+	}
+	if !strings.Contains(string(stack), "panickingJob") {
+		t.Errorf("stack does not show the job function:\n%s", stack)
+	}
+	if len(failures) != 0 {
+		t.Errorf("OnError got %v, panics go to the panic handler", failures)
+	}
+	if d, ok := job.TimeUntilNextRun(); !ok || d <= 0 {
+		t.Error("the job was not rescheduled after panicking")
+	}
+	if job.IsRunning() {
+		t.Error("a job that panicked still counts as running")
+	}
+}
+
+func TestJob_DisableAfterPanics(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+	fail := true
+	job := scheduler.Every(1).Hour().DisableAfterPanics(2)
+	job.Do(func() {
+		if fail {
+			panic("flaky")
+		}
+	})
+
+	runAllAndWait(t, scheduler)
+	if job.Panics() != 1 || job.IsPaused() {
+		t.Fatalf("after one panic: %d panics, paused %v", job.Panics(), job.IsPaused())
+	}
+	version := job.Version()
+	runAllAndWait(t, scheduler)
+	if !job.IsPaused() {
+		t.Fatal("the job was not paused after 2 panics in a row")
+	}
+	if err := job.RescheduleIfVersion(version, 2, UnitHours); err != ErrVersionConflict {
+		t.Errorf("RescheduleIfVersion() with the version before the pause = %v, want ErrVersionConflict", err)
+	}
+
+	fail = false
+	job.Resume()
+	runAllAndWait(t, scheduler)
+	if job.Panics() != 0 {
+		t.Errorf("Panics() = %d after a clean run, want 0", job.Panics())
+	}
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.lines) != 3 || !strings.Contains(logger.lines[2], "paused after 2 panics") {
+		t.Errorf("got log lines %q, want both panics and the pause", logger.lines)
+	}
+}
+
+func TestJob_PanicWithoutScheduler(t *testing.T) {
+	logger := &recordingLogger{}
+	saved := defaultLogger
+	defaultLogger = logger
+	defer func() { defaultLogger = saved }()
+
+	job := NewJob(1)
+	job.handlePanic(runResult{recovered: "x"})
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "panicked: x") {
+		t.Errorf("got log lines %q, want the panic on the package logger", logger.lines)
+	}
+}
@@ -0,0 +1,78 @@
+package gocron
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// Whether calls of a function of type typ with params get the scheduler's
+// context as their first argument: the function takes a context.Context
+// first and params do not provide one
+func injectsContext(typ reflect.Type, params []interface{}) bool {
+	if !takesContext(typ) {
+		return false
+	}
+	if !typ.IsVariadic() {
+		return len(params) == typ.NumIn()-1
+	}
+	if len(params) == 0 || params[0] == nil {
+		return true
+	}
+	return !reflect.TypeOf(params[0]).Implements(contextType)
+}
+
+// The type of param i of calls of typ, skip is 1 when the context is
+// passed first
+func paramType(typ reflect.Type, i, skip int) reflect.Type {
+	n := i + skip
+	if typ.IsVariadic() && n >= typ.NumIn()-1 {
+		return typ.In(typ.NumIn() - 1).Elem()
+	}
+	return typ.In(n)
+}
+
+// Check that params fit the signature of typ, in number and type
+func validateParams(typ reflect.Type, params []interface{}) error {
+	skip := 0
+	if injectsContext(typ, params) {
+		skip = 1
+	}
+	want := typ.NumIn() - skip
+	switch {
+	case typ.IsVariadic() && len(params) < want-1:
+		return errors.New("the function takes at least " + strconv.Itoa(want-1) + " params, got " + strconv.Itoa(len(params)))
+	case !typ.IsVariadic() && len(params) != want:
+		return errors.New("the function takes " + strconv.Itoa(want) + " params, got " + strconv.Itoa(len(params)))
+	}
+	for i, param := range params {
+		in := paramType(typ, i, skip)
+		if param == nil {
+			if !nillable(in) {
+				return errors.New("param " + strconv.Itoa(i) + " is nil, the function takes " + in.String())
+			}
+			continue
+		}
+		if got := reflect.TypeOf(param); !got.AssignableTo(in) {
+			return errors.New("param " + strconv.Itoa(i) + " is " + got.String() + ", the function takes " + in.String())
+		}
+	}
+	return nil
+}
+
+// The argument for param i of calls of typ, a nil param becomes the zero
+// value of its type
+func paramValue(typ reflect.Type, param interface{}, i, skip int) reflect.Value {
+	if param == nil {
+		return reflect.Zero(paramType(typ, i, skip))
+	}
+	return reflect.ValueOf(param)
+}
+
+func nillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
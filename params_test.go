@@ -0,0 +1,63 @@
+package gocron
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJob_DoValidatesParams(t *testing.T) {
+	scheduler := NewScheduler()
+	cases := []struct {
+		fn      interface{}
+		params  []interface{}
+		wantErr string
+	}{
+		{func(n int) {}, []interface{}{1}, ""},
+		{func(n int) {}, []interface{}{"1"}, "param 0 is string, the function takes int"},
+		{func(n int) {}, nil, "takes 1 params, got 0"},
+		{func(n int) {}, []interface{}{1, 2}, "takes 1 params, got 2"},
+		{func(s fmt.Stringer) {}, []interface{}{time.Second}, ""},
+		{func(s fmt.Stringer) {}, []interface{}{1}, "param 0 is int, the function takes fmt.Stringer"},
+		{func(s fmt.Stringer, m map[string]int, p *int) {}, []interface{}{nil, nil, nil}, ""},
+		{func(n int) {}, []interface{}{nil}, "param 0 is nil, the function takes int"},
+		{func(format string, v ...interface{}) {}, []interface{}{"x"}, ""},
+		{func(format string, v ...interface{}) {}, []interface{}{"x", 1, "a", nil}, ""},
+		{func(format string, v ...interface{}) {}, nil, "takes at least 1 params, got 0"},
+		{func(ns ...int) {}, []interface{}{1, "2"}, "param 1 is string, the function takes int"},
+		{func(ctx context.Context, n int) {}, []interface{}{1}, ""},
+		{func(ctx context.Context, n int) {}, []interface{}{context.Background(), 1}, ""},
+		{func(ctx context.Context, ns ...int) {}, []interface{}{1, 2}, ""},
+	}
+	for i, c := range cases {
+		err := scheduler.Every(1).Hour().Do(c.fn, c.params...)
+		switch {
+		case c.wantErr == "" && err != nil:
+			t.Errorf("case %d: Do() = %v, want no error", i, err)
+		case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+			t.Errorf("case %d: Do() = %v, want %q", i, err, c.wantErr)
+		}
+	}
+}
+
+func TestJob_DoVariadicAndNilParams(t *testing.T) {
+	scheduler := NewScheduler()
+	got := make(chan string, 2)
+	scheduler.Every(1).Hour().Do(func(ctx context.Context, prefix string, ns ...int) {
+		got <- fmt.Sprintln(ctx != nil, prefix, ns)
+	}, "n", 1, 2)
+	scheduler.Every(1).Hour().Do(func(m map[string]int, err error) {
+		got <- fmt.Sprintln(m == nil, err == nil)
+	}, nil, nil)
+
+	runAllAndWait(t, scheduler)
+	results := []string{<-got, <-got}
+	want := map[string]bool{"true n [1 2]\n": true, "true true\n": true}
+	for _, r := range results {
+		if !want[r] {
+			t.Errorf("unexpected call %q", r)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package gocron
+
+import "errors"
+
+// PauseMode - How a paused job picks its next run when resumed
+type PauseMode int
+
+const (
+	// PauseRecomputeOnResume moves the next run to the first slot of the
+	// job's schedule after the resume, skipping the runs missed meanwhile
+	PauseRecomputeOnResume PauseMode = iota
+	// PauseFreezeSchedule keeps the time that was left until the next run
+	// when the job was paused and owes exactly that much after the resume
+	PauseFreezeSchedule
+)
+
+// Pause - Stop running the job until Resume is called, e.g.
+// job.Pause(PauseFreezeSchedule). The mode defaults to
+// PauseRecomputeOnResume. Freezing is rejected for jobs using At, Cron,
+// Weeks, Months or weekdays, since their runs are pinned to the clock or
+// the calendar rather than an interval.
+func (j *Job) Pause(mode ...PauseMode) error {
+	m := PauseRecomputeOnResume
+	if len(mode) > 0 {
+		m = mode[0]
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if m == PauseFreezeSchedule && !j.freezable() {
+		return errors.New("cannot freeze the schedule of a job pinned to the clock or the calendar")
+	}
+	if j.paused {
+		return nil
+	}
+	j.paused = true
+	j.version++
+	j.pauseMode = m
+	j.remaining = j.nextRun.Sub(j.now())
+	return nil
+}
+
+// Whether the job's schedule is a plain interval that Pause can freeze,
+// requires j.mu held
+func (j *Job) freezable() bool {
+	return j.atTime == "" && j.cron == nil && j.weekdays == 0 &&
+		j.unit != UnitWeeks && j.unit != UnitMonths
+}
+
+// Resume - Resume a paused job
+func (j *Job) Resume() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !j.paused {
+		return
+	}
+	j.paused = false
+	j.version++
+	defer j.scheduler.wakeup()
+
+	now := j.now()
+	if j.pauseMode == PauseFreezeSchedule {
+		j.nextRun = now.Add(j.remaining)
+		return
+	}
+	if !j.nextRun.After(now) {
+		j.realign(j.nextRun, now)
+	}
+}
+
+// IsPaused - Whether the job is paused
+func (j *Job) IsPaused() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.paused
+}
+
+// Pause - Stop dispatching the scheduler's jobs until Resume, e.g. for a
+// maintenance window. The loop started with Start keeps going and the
+// jobs keep their settings and counts; runs already going are not
+// affected. RunPending dispatches nothing meanwhile, due jobs are
+// recorded as skipped with SkipPaused, while RunAll still runs them.
+func (s *Scheduler) Pause() {
+	s.mu.Lock()
+	s.paused = true
+	s.mu.Unlock()
+	s.wakeup()
+}
+
+// Resume - Dispatch the scheduler's jobs again after Pause. Jobs whose
+// runs were missed meanwhile move to the first slot of their schedule
+// after now rather than running for each of them, like a job resumed
+// with PauseRecomputeOnResume. Jobs paused on their own stay paused.
+func (s *Scheduler) Resume() {
+	s.mu.Lock()
+	if !s.paused {
+		s.mu.Unlock()
+		return
+	}
+	s.paused = false
+	s.mu.Unlock()
+
+	now := s.now()
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		if job.jobFunc != "" && !job.paused && !job.nextRun.After(now) {
+			job.realign(job.nextRun, now)
+		}
+		job.mu.Unlock()
+	}
+	s.wakeup()
+}
+
+// IsPaused - Whether the scheduler is paused, see Pause
+func (s *Scheduler) IsPaused() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paused
+}
@@ -0,0 +1,162 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+// A scheduler on a fake clock at noon UTC on Wednesday, 1 May 2024
+func pauseScheduler() (*Scheduler, *clock.Fake) {
+	scheduler := NewScheduler()
+	scheduler.ChangeLoc(time.UTC)
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	return scheduler, fake
+}
+
+func TestJob_PauseFreezeSchedule(t *testing.T) {
+	scheduler, fake := pauseScheduler()
+	job := scheduler.Every(1).Hour()
+	job.Do(task)
+
+	// pause 60% of the way through the interval
+	fake.Advance(36 * time.Minute)
+	if err := job.Pause(PauseFreezeSchedule); err != nil {
+		t.Fatal(err)
+	}
+	if !job.IsPaused() {
+		t.Error("IsPaused() = false after Pause")
+	}
+
+	// three hours later the job is overdue but must not run while paused
+	fake.Advance(3 * time.Hour)
+	scheduler.RunPendingAndWait()
+	if job.RunCount() != 0 {
+		t.Error("paused job ran")
+	}
+
+	job.Resume()
+	if left := job.NextScheduledTime().Sub(fake.Now()); left != 24*time.Minute {
+		t.Errorf("next run in %s after resume, want the frozen 40%% of the interval, 24m", left)
+	}
+	fake.Advance(24*time.Minute + time.Second)
+	scheduler.RunPendingAndWait()
+	if job.RunCount() != 1 {
+		t.Errorf("%d runs once the frozen time elapsed, want 1", job.RunCount())
+	}
+}
+
+func TestJob_PauseRecomputeOnResume(t *testing.T) {
+	scheduler, fake := pauseScheduler()
+	job := scheduler.Every(1).Hour()
+	job.Do(task)
+
+	if err := job.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	// resume two and a half hours after the missed run at 13:00
+	fake.Advance(210 * time.Minute)
+	scheduler.RunPendingAndWait()
+	job.Resume()
+
+	if want := time.Date(2024, 5, 1, 16, 0, 0, 0, time.UTC); !job.NextScheduledTime().Equal(want) {
+		t.Errorf("next run %s, want the first slot of the hourly grid after now, %s", job.NextScheduledTime(), want)
+	}
+	if job.RunCount() != 0 {
+		t.Errorf("%d runs, want the missed ones skipped", job.RunCount())
+	}
+}
+
+func TestJob_PauseFreezeAtRejected(t *testing.T) {
+	s := NewScheduler()
+	for _, job := range []*Job{
+		s.Every(1).Day().At("10:30"),
+		s.Cron("30 10 * * *"),
+		s.Every(1).Month().DayOfTheMonth(15),
+		s.Every(1).Monday(),
+		s.Every(1).Monday().Wednesday(),
+		s.Every(2).Weeks(),
+	} {
+		if err := job.Do(task); err != nil {
+			t.Fatal(err)
+		}
+		if err := job.Pause(PauseFreezeSchedule); err == nil {
+			t.Errorf("freezing %s should fail", job)
+		}
+		if job.IsPaused() {
+			t.Errorf("a rejected Pause must leave %s running", job)
+		}
+	}
+}
+
+func TestJob_PauseBumpsVersion(t *testing.T) {
+	job := NewScheduler().Every(1).Hour()
+	job.Do(task)
+	v := job.Version()
+	job.Pause()
+	paused := job.Version()
+	job.Pause() // already paused
+	if paused != v+1 || job.Version() != paused {
+		t.Errorf("Pause took the version from %d to %d, want one bump", v, job.Version())
+	}
+	job.Resume()
+	job.Resume()
+	if job.Version() != v+2 {
+		t.Errorf("Resume left the version at %d, want %d", job.Version(), v+2)
+	}
+	if err := job.RescheduleIfVersion(v, 2, UnitHours); err != ErrVersionConflict {
+		t.Errorf("RescheduleIfVersion() over a pause = %v, want ErrVersionConflict", err)
+	}
+}
+
+func TestScheduler_Pause(t *testing.T) {
+	scheduler, fake := pauseScheduler()
+	hourly := scheduler.Every(1).Hour().Tag("sync")
+	hourly.Do(task)
+	daily := scheduler.Every(1).Day().At("02:00")
+	daily.Do(task)
+	scheduler.Pause()
+	if !scheduler.IsPaused() {
+		t.Fatal("IsPaused() = false after Pause")
+	}
+
+	// both are three days overdue by the end of the window
+	fake.Advance(3 * 24 * time.Hour)
+	scheduler.RunPendingAndWait()
+	pass := scheduler.RecentDispatchPasses(1)[0]
+	if len(pass.Dispatched) != 0 || len(pass.Skipped) != 2 || pass.Skipped[0].Reason != SkipPaused {
+		t.Errorf("pass dispatched %v and skipped %v, want both skipped as paused", pass.Dispatched, pass.Skipped)
+	}
+	if hourly.RunCount() != 0 || daily.RunCount() != 0 {
+		t.Error("a job ran while the scheduler was paused")
+	}
+	if d := scheduler.untilNextWake(); d != maxWake {
+		t.Errorf("the loop wakes up in %v while paused, want %v", d, maxWake)
+	}
+
+	scheduler.Resume()
+	if want := time.Date(2024, 5, 4, 13, 0, 0, 0, time.UTC); !hourly.NextScheduledTime().Equal(want) {
+		t.Errorf("hourly job next at %v after resume, want %v", hourly.NextScheduledTime(), want)
+	}
+	if want := time.Date(2024, 5, 5, 2, 0, 0, 0, time.UTC); !daily.NextScheduledTime().Equal(want) {
+		t.Errorf("daily job next at %v after resume, want %v", daily.NextScheduledTime(), want)
+	}
+	scheduler.RunPendingAndWait()
+	if hourly.RunCount() != 0 || daily.RunCount() != 0 || len(hourly.Tags()) != 1 {
+		t.Error("resuming ran the missed runs or lost the job's settings")
+	}
+}
+
+func TestJob_ResumeAt(t *testing.T) {
+	scheduler, fake := pauseScheduler()
+	job := scheduler.Every(1).Day().At("02:00")
+	job.Do(task)
+	job.Pause()
+	fake.Advance(3 * 24 * time.Hour)
+	job.Resume()
+	if want := time.Date(2024, 5, 5, 2, 0, 0, 0, time.UTC); !job.NextScheduledTime().Equal(want) {
+		t.Errorf("next run %v after resume, want %v", job.NextScheduledTime(), want)
+	}
+}
@@ -0,0 +1,69 @@
+package gocron
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+)
+
+// EveryRandom - Schedule a new job whose runs are a random lower to upper
+// units apart, drawn afresh after every run, e.g. s.EveryRandom(50, 70).Seconds().Do(task)
+// spreads the runs of many instances of a job. See SetRandSource.
+func (s *Scheduler) EveryRandom(lower, upper uint64) *Job {
+	job := s.Every(lower)
+	if lower == 0 || upper < lower {
+		job.setErr(errors.New("EveryRandom() requires 0 < lower <= upper, got " +
+			strconv.FormatUint(lower, 10) + " and " + strconv.FormatUint(upper, 10)))
+		return job
+	}
+	job.randomUpper = upper
+	return job
+}
+
+// EveryRandom - Schedule a new job with a random interval on the default scheduler
+func EveryRandom(lower, upper uint64) *Job {
+	return defaultScheduler().EveryRandom(lower, upper)
+}
+
+// SetRandSource - Draw the intervals of EveryRandom jobs from r instead of
+// the math/rand default source, e.g. rand.New(rand.NewSource(42)) for
+// repeatable schedules in tests
+func (s *Scheduler) SetRandSource(r *rand.Rand) {
+	s.rndMu.Lock()
+	defer s.rndMu.Unlock()
+	s.rnd = r
+}
+
+// A random int in [0, n), s may be nil for jobs made with NewJob
+func (s *Scheduler) randN(n int64) int64 {
+	if s != nil {
+		s.rndMu.Lock()
+		defer s.rndMu.Unlock()
+		if s.rnd != nil {
+			return s.rnd.Int63n(n)
+		}
+	}
+	return rand.Int63n(n)
+}
+
+// Check a random interval against the rest of the schedule
+func (j *Job) checkRandom() error {
+	if j.randomUpper == 0 {
+		return nil
+	}
+	switch {
+	case j.unit == UnitMonths || j.unit == unitDuration || j.cron != nil:
+		return errors.New("EveryRandom() needs a unit from seconds to weeks")
+	case j.atTime != "":
+		return errors.New("EveryRandom() cannot be combined with At()")
+	}
+	return nil
+}
+
+// The interval until the next run, drawn for EveryRandom jobs
+func (j *Job) nextInterval() uint64 {
+	if j.randomUpper == 0 {
+		return j.interval
+	}
+	return j.interval + uint64(j.scheduler.randN(int64(j.randomUpper-j.interval+1)))
+}
@@ -0,0 +1,73 @@
+package gocron
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The gaps between the next n runs of job
+func runGaps(job *Job, n int) []time.Duration {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	var gaps []time.Duration
+	for i := 0; i < n; i++ {
+		prev := job.nextRun
+		job.claim(prev)
+		gaps = append(gaps, job.nextRun.Sub(prev))
+	}
+	return gaps
+}
+
+func TestScheduler_EveryRandom(t *testing.T) {
+	seeded := func() *Job {
+		scheduler := NewScheduler()
+		scheduler.SetRandSource(rand.New(rand.NewSource(42)))
+		job := scheduler.EveryRandom(50, 70).Seconds()
+		if err := job.Do(func() {}); err != nil {
+			t.Fatal(err)
+		}
+		return job
+	}
+	if got := seeded().String(); !strings.Contains(got, "] every 50 to 70 seconds, next ") {
+		t.Errorf("String() = %q", got)
+	}
+	a, b := runGaps(seeded(), 50), runGaps(seeded(), 50)
+	distinct := map[time.Duration]bool{}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("gap %d differs between equally seeded schedulers: %v and %v", i, a[i], b[i])
+		}
+		if a[i] < 50*time.Second || a[i] > 70*time.Second || a[i]%time.Second != 0 {
+			t.Errorf("gap %d is %v, want whole seconds from 50s to 70s", i, a[i])
+		}
+		distinct[a[i]] = true
+	}
+	if len(distinct) < 5 {
+		t.Errorf("got only %d distinct gaps in %d runs", len(distinct), len(a))
+	}
+
+	scheduler := NewScheduler()
+	fixed := scheduler.EveryRandom(3, 3).Minutes()
+	fixed.Do(func() {})
+	for _, gap := range runGaps(fixed, 3) {
+		if gap != 3*time.Minute {
+			t.Errorf("EveryRandom(3, 3) gap %v, want 3m", gap)
+		}
+	}
+}
+
+func TestScheduler_EveryRandomErrors(t *testing.T) {
+	scheduler := NewScheduler()
+	for _, job := range []*Job{
+		scheduler.EveryRandom(0, 10).Seconds(),
+		scheduler.EveryRandom(10, 5).Seconds(),
+		scheduler.EveryRandom(1, 2).Days().At("10:30"),
+		scheduler.EveryRandom(1, 2).Months(),
+	} {
+		if err := job.Do(func() {}); err == nil {
+			t.Errorf("%s should fail", job)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gocron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// RedactedParam stands in for a redacted param wherever params are shown
+const RedactedParam = "[REDACTED]"
+
+// SecretParam - A param that is never shown, see Secret
+type SecretParam struct {
+	value interface{}
+}
+
+// Secret - Wrap a param so that it is always redacted, e.g.
+// s.Every(1).Hour().Do(callAPI, gocron.Secret(token))
+// The function is still called with the value itself, and fingerprints
+// such as the idempotency key hash it, but the scheduler only ever
+// renders it as RedactedParam.
+func Secret(v interface{}) SecretParam {
+	return SecretParam{value: v}
+}
+
+// String renders RedactedParam
+func (SecretParam) String() string {
+	return RedactedParam
+}
+
+// Format renders RedactedParam for every verb, so %#v and %d do not
+// print the wrapped value either
+func (SecretParam) Format(f fmt.State, verb rune) {
+	f.Write([]byte(RedactedParam))
+}
+
+// MarshalJSON renders RedactedParam as a JSON string
+func (SecretParam) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(RedactedParam)), nil
+}
+
+// RedactParams - Redact the params given to Do at indices, counting from 0,
+// like params wrapped with Secret
+func (j *Job) RedactParams(indices ...int) *Job {
+	if !j.building("RedactParams") {
+		return j
+	}
+	for _, i := range indices {
+		if i < 0 {
+			j.setErr(errors.New("RedactParams() got a negative index " + strconv.Itoa(i)))
+			return j
+		}
+	}
+	j.redact = append(j.redact, indices...)
+	return j
+}
+
+// Params - The params given to Do, with the redacted ones replaced by
+// RedactedParam
+func (j *Job) Params() []interface{} {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	params := append([]interface{}(nil), j.params...)
+	for _, i := range j.redact {
+		params[i] = RedactedParam
+	}
+	return params
+}
+
+// Unwrap the secrets among the params given to Do, returning the indices
+// they were at. params is only copied when it holds a secret.
+func revealSecrets(params []interface{}) ([]interface{}, []int) {
+	var at []int
+	for i, p := range params {
+		if s, ok := p.(SecretParam); ok {
+			if at == nil {
+				params = append([]interface{}(nil), params...)
+			}
+			params[i] = s.value
+			at = append(at, i)
+		}
+	}
+	return params, at
+}
+
+// Check the RedactParams indices against the number of params given to Do
+func (j *Job) checkRedact(n int) error {
+	for _, i := range j.redact {
+		if i >= n {
+			return errors.New("RedactParams() index " + strconv.Itoa(i) + " but Do got " + strconv.Itoa(n) + " params")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package gocron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testSecret = "tok-0123456789abcdef"
+
+func TestSecret_Renders(t *testing.T) {
+	s := Secret(testSecret)
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%d", "%x"} {
+		if got := fmt.Sprintf(verb, s); got != RedactedParam {
+			t.Errorf("Sprintf(%q) = %q, want %q", verb, got, RedactedParam)
+		}
+	}
+	if b, _ := json.Marshal(s); string(b) != `"[REDACTED]"` {
+		t.Errorf("json.Marshal() = %s", b)
+	}
+	plain, _ := FingerprintParams("user", testSecret)
+	wrapped, _ := FingerprintParams("user", s)
+	if plain != wrapped {
+		t.Error("a Secret should fingerprint as its value")
+	}
+}
+
+func TestJob_RedactParams(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Hour().RedactParams(1)
+	if err := job.Do(func(user, token string, n int) {}, "ann", testSecret, 3); err != nil {
+		t.Fatal(err)
+	}
+	params := job.Params()
+	if params[0] != "ann" || params[1] != RedactedParam || params[2] != 3 {
+		t.Errorf("Params() = %v, want the token redacted", params)
+	}
+
+	if err := scheduler.Every(1).Hour().RedactParams(2).Do(func(string) {}, "x"); err == nil {
+		t.Error("redacting a param Do is not given should fail")
+	}
+	if err := scheduler.Every(1).Hour().RedactParams(-1).Do(task); err == nil {
+		t.Error("a negative index should fail")
+	}
+	if err := scheduler.Every(1).Hour().Do(func(int) {}, Secret("x")); err == nil {
+		t.Error("a Secret should be checked against the function by its value")
+	}
+}
+
+// Everything the scheduler renders about a run of a job with secret
+// params, none of which may show them
+func TestRedact_Audit(t *testing.T) {
+	logger := &recordingLogger{}
+	live := NewScheduler()
+	live.SetLogger(logger)
+	live.SetDebug(true)
+	var outputs []string
+	live.SetErrorHandler(func(j *Job, err error) {
+		outputs = append(outputs, err.Error())
+	})
+
+	var got []string
+	var keys []string
+	fn := func(ctx context.Context, user, token string, opts map[string]string) error {
+		got = append(got, token, opts["password"])
+		if key, ok := IdempotencyKey(ctx); ok {
+			keys = append(keys, key)
+		}
+		opts["password"] = "changed"
+		return errors.New("upstream refused " + user)
+	}
+	opts := map[string]string{"password": testSecret + "-2"}
+	j := live.Every(1).Hour().RedactParams(2)
+	if err := j.Do(fn, "ann", Secret(testSecret), opts); err != nil {
+		t.Fatal(err)
+	}
+	runAllAndWait(t, live)
+
+	shadow := NewScheduler()
+	shadow.SetShadowMode(true)
+	shadow.SetShadowRecorder(func(r WouldHaveRun) {
+		outputs = append(outputs, fmt.Sprintf("%+v %v", r, r.Params))
+	})
+	shadow.Every(1).Hour().StartImmediately().Do(fn, "ann", Secret(testSecret), opts)
+	shadow.RunPending()
+
+	if len(got) != 2 || got[0] != testSecret || got[1] != testSecret+"-2" {
+		t.Fatalf("the function got %q, want the real values", got)
+	}
+	want, _ := FingerprintParams("ann", testSecret, map[string]string{"password": testSecret + "-2"})
+	if len(keys) != 1 || !strings.HasSuffix(keys[0], "/"+want) {
+		t.Errorf("idempotency keys %q, want them to hash the real values", keys)
+	}
+
+	params := j.Params()
+	b, _ := json.Marshal(params)
+	outputs = append(outputs, keys...)
+	outputs = append(outputs,
+		j.String(),
+		fmt.Sprintf("%v %+v %#v %d", params, params, params, params),
+		string(b),
+		schedulerVar{live}.String(),
+		fmt.Sprintf("%+v", live.RecentDispatchPasses(DispatchLogSize)),
+	)
+	logger.mu.Lock()
+	outputs = append(outputs, logger.lines...)
+	logger.mu.Unlock()
+	if len(logger.lines) == 0 {
+		t.Error("the params changing during the run were not logged")
+	}
+	for _, out := range outputs {
+		if strings.Contains(out, testSecret) {
+			t.Errorf("secret found in %q", out)
+		}
+	}
+}
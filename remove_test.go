@@ -0,0 +1,177 @@
+package gocron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJob_RemoveSelf(t *testing.T) {
+	scheduler := NewScheduler()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		job := scheduler.Every(1).Second()
+		job.Do(func(j **Job) {
+			(*j).RemoveSelf()
+			wg.Done()
+		}, &job)
+	}
+	keep := scheduler.Every(1).Hour()
+	keep.Do(func() {})
+
+	scheduler.RunAll()
+	wg.Wait()
+	scheduler.RunPending()
+
+	if len(scheduler.jobs) != 1 || scheduler.jobs[0] != keep {
+		t.Errorf("expected only the hourly job to remain, have %d jobs", len(scheduler.jobs))
+	}
+}
+
+func TestScheduler_RemoveFromRun(t *testing.T) {
+	scheduler := NewScheduler()
+	var mu sync.Mutex
+	immediate := 0
+	// still in the jobs after removing itself, the removal was deferred
+	deferred := func(j *Job) {
+		scheduler.mu.RLock()
+		defer scheduler.mu.RUnlock()
+		for _, job := range scheduler.jobs {
+			if job == j {
+				return
+			}
+		}
+		mu.Lock()
+		immediate++
+		mu.Unlock()
+	}
+	for i := 0; i < 50; i++ {
+		job := scheduler.Every(1).Second()
+		job.Do(func(j **Job) {
+			scheduler.RemoveByReference(*j)
+			deferred(*j)
+		}, &job)
+	}
+	for i := 0; i < 50; i++ {
+		scheduler.Every(1).Second().SetEventListeners(nil, func(j *Job, _ time.Duration) {
+			scheduler.RemoveByID(j.ID())
+			deferred(j)
+		}).Do(func() {})
+	}
+	keep := scheduler.Every(1).Hour()
+	keep.Do(task)
+
+	runAllAndWait(t, scheduler)
+	if jobs := scheduler.Jobs(); len(jobs) != 1 || jobs[0] != keep {
+		t.Errorf("expected only the hourly job to remain, have %d jobs", len(jobs))
+	}
+	if immediate > 0 {
+		t.Errorf("%d removals from a run changed the jobs right away", immediate)
+	}
+	if !scheduler.RemoveByReference(keep) || len(scheduler.jobs) != 0 {
+		t.Error("a removal outside a run should apply right away")
+	}
+}
+
+func flush(kind string) {}
+
+func TestScheduler_RemoveFirstByFunction(t *testing.T) {
+	scheduler := NewScheduler()
+	daily := scheduler.Every(1).Day()
+	daily.Do(flush, "daily")
+	hourly := scheduler.Every(1).Hour()
+	hourly.Do(flush, "hourly")
+	scheduler.Every(1).Minute().Do(task)
+
+	// the hourly job sorts ahead of the daily one from here on
+	for i := 0; i < 3; i++ {
+		scheduler.NextRun()
+		scheduler.RunPending()
+	}
+	if !scheduler.RemoveFirstByFunction(flush) {
+		t.Fatal("RemoveFirstByFunction found no job")
+	}
+	for _, job := range scheduler.Jobs() {
+		if job == daily {
+			t.Error("RemoveFirstByFunction should remove the job added first, the daily flush")
+		}
+	}
+	if len(scheduler.Jobs()) != 2 {
+		t.Errorf("have %d jobs, want 2", len(scheduler.Jobs()))
+	}
+	if scheduler.RemoveFirstByFunction(taskWithParams) {
+		t.Error("RemoveFirstByFunction reported removing a function that was never scheduled")
+	}
+}
+
+func TestScheduler_RemoveAllByFunction(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.Every(1).Day().Do(flush, "daily")
+	scheduler.Every(1).Hour().Do(flush, "hourly")
+	keep := scheduler.Every(1).Minute()
+	keep.Do(task)
+
+	if n := scheduler.RemoveAllByFunction(flush); n != 2 {
+		t.Errorf("RemoveAllByFunction removed %d jobs, want 2", n)
+	}
+	if jobs := scheduler.Jobs(); len(jobs) != 1 || jobs[0] != keep {
+		t.Error("RemoveAllByFunction should keep jobs running other functions")
+	}
+}
+
+func TestScheduler_RemoveDeprecated(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+	scheduler.Every(1).Day().Do(flush, "daily")
+	scheduler.Every(1).Hour().Do(flush, "hourly")
+
+	if !scheduler.Remove(flush) {
+		t.Error("Remove should report removing the flush jobs")
+	}
+	if scheduler.Remove(flush) {
+		t.Error("Remove should report false once nothing matches")
+	}
+	if len(scheduler.Jobs()) != 0 {
+		t.Errorf("have %d jobs, want 0", len(scheduler.Jobs()))
+	}
+	if len(logger.lines) != 1 {
+		t.Errorf("got %d deprecation warnings, want 1", len(logger.lines))
+	}
+}
+
+func TestScheduler_RemoveNoMatch(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetLogger(nil)
+	if scheduler.Remove(task) {
+		t.Error("Remove on an empty scheduler should report false")
+	}
+
+	scheduler.Every(1).Hour().Do(task)
+	scheduler.Every(1).Day().Do(flush, "daily")
+	if scheduler.Remove(taskWithParams) {
+		t.Error("Remove of an unscheduled function should report false")
+	}
+	if len(scheduler.Jobs()) != 2 {
+		t.Errorf("Remove of an unscheduled function changed the jobs, have %d", len(scheduler.Jobs()))
+	}
+}
+
+func TestScheduler_RemoveByReference(t *testing.T) {
+	scheduler := NewScheduler()
+	acme := scheduler.Every(1).Hour()
+	acme.Do(flush, "acme")
+	globex := scheduler.Every(1).Hour()
+	globex.Do(flush, "globex")
+
+	if !scheduler.RemoveByReference(acme) {
+		t.Fatal("RemoveByReference should find the job")
+	}
+	if jobs := scheduler.Jobs(); len(jobs) != 1 || jobs[0] != globex {
+		t.Error("RemoveByReference should keep the other job running the same function")
+	}
+	if scheduler.RemoveByReference(acme) {
+		t.Error("RemoveByReference of a removed job should report false")
+	}
+}
@@ -0,0 +1,90 @@
+package gocron
+
+import "reflect"
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// OnError - Call fn with the error whenever the job's function returns a
+// non-nil error as its last result
+func (j *Job) OnError(fn func(err error)) *Job {
+	j.mu.Lock()
+	j.onError = fn
+	j.mu.Unlock()
+	return j
+}
+
+// OnSuccess - Call fn whenever a run of the job returns without an error
+func (j *Job) OnSuccess(fn func()) *Job {
+	j.mu.Lock()
+	j.onSuccess = fn
+	j.mu.Unlock()
+	return j
+}
+
+// SetErrorHandler - Call fn whenever a job of the scheduler returns a
+// non-nil error as its last result, in addition to the job's own OnError
+func (s *Scheduler) SetErrorHandler(fn func(job *Job, err error)) {
+	s.mu.Lock()
+	s.errorHandler = fn
+	s.mu.Unlock()
+}
+
+// The error a job's function returned, nil if its last result is not a non-nil error
+func resultError(out []reflect.Value) error {
+	if len(out) == 0 {
+		return nil
+	}
+	last := out[len(out)-1]
+	if !last.Type().Implements(errorType) {
+		return nil
+	}
+	switch last.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if last.IsNil() {
+			return nil
+		}
+	}
+	err, _ := last.Interface().(error)
+	return err
+}
+
+// The error of a run that did not panic, nil if it succeeded
+func (r runResult) err() error {
+	if r.timedOut {
+		return ErrTimeout
+	}
+	err := resultError(r.out)
+	if err != nil && r.attempts > 1 {
+		err = &RetriesExhaustedError{Attempts: r.attempts, Err: err}
+	}
+	return err
+}
+
+// Pass the error of a run, nil if it succeeded, to the job's and
+// scheduler's handlers. Handlers run on the job's goroutine. err is
+// returned.
+func (j *Job) handleResult(err error) error {
+
+	j.mu.Lock()
+	onError, onSuccess, s := j.onError, j.onSuccess, j.scheduler
+	j.mu.Unlock()
+
+	if err == nil {
+		if onSuccess != nil {
+			onSuccess()
+		}
+		return nil
+	}
+	if onError != nil {
+		onError(err)
+	}
+	if s != nil {
+		s.mu.RLock()
+		handler := s.errorHandler
+		s.mu.RUnlock()
+		if handler != nil {
+			handler(j, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package gocron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScheduler_SetErrorHandler(t *testing.T) {
+	scheduler := NewScheduler()
+	boom := errors.New("boom")
+	type report struct {
+		job *Job
+		err error
+	}
+	reports := make(chan report, 1)
+	scheduler.SetErrorHandler(func(job *Job, err error) {
+		reports <- report{job, err}
+	})
+	jobErrs := make(chan error, 1)
+
+	job := scheduler.Every(1).Second().OnError(func(err error) { jobErrs <- err })
+	job.Do(func() error { return boom })
+	scheduler.RunAll()
+
+	select {
+	case r := <-reports:
+		if r.job != job || r.err != boom {
+			t.Errorf("handler got %v for %p, want boom for %p", r.err, r.job, job)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("scheduler error handler was not called")
+	}
+	if err := <-jobErrs; err != boom {
+		t.Errorf("OnError got %v, want boom", err)
+	}
+}
+
+func TestJob_OnSuccess(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetErrorHandler(func(job *Job, err error) {
+		t.Errorf("unexpected error %v", err)
+	})
+	done := make(chan string, 3)
+
+	scheduler.Every(1).Second().OnSuccess(func() { done <- "nil error" }).Do(func() error { return nil })
+	scheduler.Every(1).Second().OnSuccess(func() { done <- "no results" }).Do(func() {})
+	scheduler.Every(1).Second().OnSuccess(func() { done <- "value and nil error" }).Do(func() (int, error) { return 1, nil })
+	scheduler.RunAll()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("OnSuccess was not called for every job")
+		}
+	}
+}
+
+func TestResultError(t *testing.T) {
+	var nilPtr *customError
+	tests := []struct {
+		name string
+		fn   interface{}
+		want bool
+	}{
+		{"no results", func() {}, false},
+		{"non-error result", func() int { return 1 }, false},
+		{"nil error", func() error { return nil }, false},
+		{"error", func() error { return errors.New("x") }, true},
+		{"concrete error type", func() *customError { return &customError{} }, true},
+		{"nil concrete error", func() *customError { return nilPtr }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := reflect.ValueOf(tt.fn).Call(nil)
+			if got := resultError(out) != nil; got != tt.want {
+				t.Errorf("resultError() != nil is %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type customError struct{}
+
+func (*customError) Error() string { return "custom" }
@@ -0,0 +1,80 @@
+package gocron
+
+import "time"
+
+// ResumePolicy - Where interval jobs pick up when a stopped scheduler is
+// started again, see SetResumePolicy
+type ResumePolicy int
+
+const (
+	// ResumeRealign runs interval jobs as far from the restart as their
+	// next run was from the stop, so the time stopped is skipped as if
+	// the scheduler had been paused
+	ResumeRealign ResumePolicy = iota
+	// ResumeGrid keeps interval jobs on the times they had before the
+	// stop: the first one after the restart is the next slot of the
+	// pre-stop schedule, the slots missed meanwhile are not run
+	ResumeGrid
+)
+
+// SetResumePolicy - Set what Start does with interval jobs after the
+// scheduler was stopped, ResumeRealign by default. Interval jobs are
+// those counting a fixed period from their last run: seconds, minutes,
+// hours, days without At, EveryDuration and EveryRandom. Jobs with At
+// times, cron, weekly and monthly jobs keep their next run, so a run
+// missed while stopped is handled like any other, see RunMissed. Either
+// way no occurrence runs more than once.
+func (s *Scheduler) SetResumePolicy(p ResumePolicy) {
+	s.mu.Lock()
+	s.resumePolicy = p
+	s.mu.Unlock()
+}
+
+// Whether the job's runs are a fixed period apart from the last one,
+// requires j.mu held
+func (j *Job) intervalOnly() bool {
+	if j.cron != nil || len(j.ats) > 0 || j.hasOffset() {
+		return false
+	}
+	switch j.unit {
+	case UnitSeconds, UnitMinutes, UnitHours, UnitDays, unitDuration:
+		return true
+	}
+	return false
+}
+
+// Move the interval jobs of a scheduler stopped at stopped and started
+// again at now, following the resume policy
+func (s *Scheduler) resume(stopped, now time.Time) {
+	s.mu.RLock()
+	policy := s.resumePolicy
+	s.mu.RUnlock()
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		if job.jobFunc != "" && job.intervalOnly() && job.period > 0 {
+			job.nextRun = resumedRun(policy, job.nextRun, job.period, stopped, now)
+		}
+		job.mu.Unlock()
+	}
+}
+
+// The run after a restart at now of a job that was to run at next, every
+// period, when the scheduler stopped at stopped
+func resumedRun(policy ResumePolicy, next time.Time, period time.Duration, stopped, now time.Time) time.Time {
+	if policy == ResumeGrid {
+		if next.Before(now) {
+			next = next.Add((now.Sub(next) + period - 1) / period * period)
+		}
+		return next
+	}
+	left := next.Sub(stopped)
+	switch {
+	case left < 0:
+		// it was due when the scheduler stopped, so it runs right away
+		left = 0
+	case left > period:
+		// added while the scheduler was stopped
+		left = period
+	}
+	return now.Add(left)
+}
@@ -0,0 +1,119 @@
+package gocron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Resume(t *testing.T) {
+	stopped := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	now := stopped.Add(10*time.Second + 300*time.Millisecond)
+	cases := []struct {
+		policy ResumePolicy
+		next   time.Time
+		want   time.Time
+	}{
+		// 400ms were left of the period when the scheduler stopped
+		{ResumeRealign, stopped.Add(400 * time.Millisecond), now.Add(400 * time.Millisecond)},
+		{ResumeGrid, stopped.Add(400 * time.Millisecond), stopped.Add(10400 * time.Millisecond)},
+		// due when it stopped
+		{ResumeRealign, stopped.Add(-200 * time.Millisecond), now},
+		{ResumeGrid, stopped.Add(-200 * time.Millisecond), stopped.Add(10800 * time.Millisecond)},
+		// added while stopped
+		{ResumeRealign, stopped.Add(6 * time.Second), now.Add(time.Second)},
+		{ResumeGrid, now.Add(time.Second / 2), now.Add(time.Second / 2)},
+	}
+	for _, c := range cases {
+		scheduler := NewScheduler()
+		scheduler.SetResumePolicy(c.policy)
+		job := scheduler.Every(1).Second()
+		daily := scheduler.Every(1).Day().At("10:30")
+		job.Do(task)
+		daily.Do(task)
+		dailyNext := daily.NextScheduledTime()
+		job.nextRun = c.next
+
+		scheduler.resume(stopped, now)
+		if got := job.NextScheduledTime(); !got.Equal(c.want) {
+			t.Errorf("policy %d, next run %v: resumed at %v, want %v", c.policy, c.next.Sub(stopped), got.Sub(stopped), c.want.Sub(stopped))
+		}
+		if !daily.NextScheduledTime().Equal(dailyNext) {
+			t.Error("a job with an At time was moved")
+		}
+
+		// the runs after the restart keep the period from the resumed one
+		job.mu.Lock()
+		for i := 1; i <= 3; i++ {
+			due := job.claim(job.nextRun.Add(10 * time.Millisecond))
+			if want := c.want.Add(time.Duration(i-1) * time.Second); !due.Equal(want) {
+				t.Errorf("policy %d: run %d after the restart at %v, want %v", c.policy, i, due.Sub(stopped), want.Sub(stopped))
+			}
+		}
+		job.mu.Unlock()
+	}
+}
+
+func TestScheduler_StopStartNoBurst(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.EveryDuration(50 * time.Millisecond)
+	job.Do(task)
+	scheduler.StartWithContext(context.Background())
+	time.Sleep(120 * time.Millisecond)
+	scheduler.Stop()
+	before := job.RunCount()
+	time.Sleep(300 * time.Millisecond)
+
+	scheduler.StartWithContext(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	scheduler.Stop()
+	scheduler.Wait(time.Second)
+	if n := job.RunCount() - before; n > 1 {
+		t.Errorf("%d runs right after the restart, want at most one", n)
+	}
+}
+
+func TestJob_RunMissed(t *testing.T) {
+	scheduler := NewScheduler()
+	runs := make(chan struct{}, 10)
+	job := scheduler.Every(1).Minute().RunMissed(true)
+	job.Do(func() { runs <- struct{}{} })
+
+	// the machine slept through an hour of runs
+	due := time.Now().Add(-time.Hour - 30*time.Second)
+	job.lastRun = due.Add(-time.Minute)
+	job.nextRun = due
+	scheduler.RunPending()
+	scheduler.RunPending()
+
+	<-runs
+	select {
+	case <-runs:
+		t.Error("missed runs must be run once, not replayed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	next := job.NextScheduledTime()
+	if !next.After(time.Now()) || next.Sub(time.Now()) > time.Minute {
+		t.Errorf("next run %s should be within the next minute", next)
+	}
+	if next.Sub(due)%time.Minute != 0 {
+		t.Errorf("next run %s drifted off the schedule of %s", next, due)
+	}
+}
+
+func TestJob_RunMissedDaily(t *testing.T) {
+	job := NewScheduler().Every(1).Day().At("10:30").RunMissed(true)
+	job.Do(task)
+
+	now := time.Now()
+	due := job.nextRun.AddDate(0, 0, -3)
+	job.scheduleAfterRun(due, now)
+	next := job.nextRun
+	if !next.After(now) || next.Sub(now) > 24*time.Hour || next.Hour() != 10 || next.Minute() != 30 {
+		t.Errorf("next run %s should be the next 10:30", next)
+	}
+	if !job.lastRun.Equal(now) {
+		t.Errorf("lastRun = %s, want the time of the run", job.lastRun)
+	}
+}
@@ -0,0 +1,58 @@
+package gocron
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Retry - Call the job's function again, up to attempts more times, while
+// a run returns a non-nil error as its last result or panics, waiting backoff before
+// the first retry and twice as long before each following one. Retries
+// belong to the run that failed: they keep its place in singleton mode
+// and under the concurrency limit, and do not count in RunCount or
+// LimitRunsTo. OnError and the error handler hear of the failure only
+// once the retries are used up, with a *RetriesExhaustedError, and the
+// job then goes on with its normal schedule. When the last call panicked,
+// the panic handler hears of it as well, and the run counts once towards
+// DisableAfterPanics. The backoff ends early when the scheduler's context
+// is done.
+func (j *Job) Retry(attempts int, backoff time.Duration) *Job {
+	if !j.building("Retry") {
+		return j
+	}
+	if attempts < 1 || backoff < 0 {
+		j.setErr(errors.New("Retry() requires a positive number of attempts and a backoff of at least 0"))
+		return j
+	}
+	j.mu.Lock()
+	j.retryAttempts = attempts
+	j.retryBackoff = backoff
+	j.mu.Unlock()
+	return j
+}
+
+// Retries - How many times the job's function was called again after a
+// run returned an error, see Retry
+func (j *Job) Retries() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.retries
+}
+
+// RetriesExhaustedError - The error of a run that still failed after all
+// its retries, wrapping the last error the function returned or the panic
+type RetriesExhaustedError struct {
+	// Attempts counts the calls, the run and its retries
+	Attempts int
+	Err      error
+}
+
+func (e *RetriesExhaustedError) Error() string {
+	return "retries exhausted after " + strconv.Itoa(e.Attempts) + " attempts: " + e.Err.Error()
+}
+
+// Unwrap - The last error the function returned or the panic, for errors.Is and errors.As
+func (e *RetriesExhaustedError) Unwrap() error {
+	return e.Err
+}
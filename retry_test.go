@@ -0,0 +1,165 @@
+package gocron
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestJob_Retry(t *testing.T) {
+	scheduler := NewScheduler()
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	scheduler.SetClock(fake)
+	var mu sync.Mutex
+	var failures []error
+	calls := make(chan time.Time, 10)
+	n := 0
+	job := scheduler.Every(1).Hour().Retry(3, time.Second).OnError(func(err error) {
+		mu.Lock()
+		failures = append(failures, err)
+		mu.Unlock()
+	})
+	job.Do(func() error {
+		calls <- fake.Now()
+		n++
+		if n <= 2 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+
+	fake.Advance(time.Hour + time.Second)
+	scheduler.RunPending()
+	want := <-calls
+	// each retry waits on the fake clock, twice as long as the one before
+	for _, backoff := range []time.Duration{time.Second, 2 * time.Second} {
+		fake.BlockUntil(1)
+		fake.Advance(backoff)
+		want = want.Add(backoff)
+		if at := <-calls; !at.Equal(want) {
+			t.Errorf("retry at %v, want %v", at, want)
+		}
+	}
+	<-scheduler.inflight.idle()
+	if job.RunCount() != 1 || job.Retries() != 2 {
+		t.Errorf("%d runs and %d retries, want 1 run retried twice", job.RunCount(), job.Retries())
+	}
+	mu.Lock()
+	if len(failures) != 0 {
+		t.Errorf("OnError got %v, a run that succeeded on retry did not fail", failures)
+	}
+	mu.Unlock()
+	if next := start.Add(2 * time.Hour); !job.NextRun().Equal(next) {
+		t.Errorf("NextRun() = %v, want the normal schedule at %v", job.NextRun(), next)
+	}
+
+	fake.Set(start.Add(2*time.Hour + time.Second))
+	scheduler.RunPendingAndWait()
+	if len(calls) != 1 || job.RunCount() != 2 || job.Retries() != 2 {
+		t.Errorf("%d calls, %d runs, %d retries, want the next run on schedule", len(calls), job.RunCount(), job.Retries())
+	}
+}
+
+func TestJob_RetryExhausted(t *testing.T) {
+	scheduler := NewScheduler()
+	var got error
+	scheduler.SetErrorHandler(func(job *Job, err error) { got = err })
+	calls := 0
+	failure := errors.New("down")
+	job := scheduler.Every(1).Hour().Retry(2, 0)
+	job.Do(func() error {
+		calls++
+		return failure
+	})
+
+	scheduler.RunAll()
+	<-scheduler.inflight.idle()
+	if calls != 3 {
+		t.Errorf("%d calls, want the run and 2 retries", calls)
+	}
+	exhausted, ok := got.(*RetriesExhaustedError)
+	if !ok || exhausted.Attempts != 3 || exhausted.Unwrap() != failure {
+		t.Fatalf("error handler got %v, want the retries exhausted", got)
+	}
+	if want := "retries exhausted after 3 attempts: down"; got.Error() != want {
+		t.Errorf("Error() = %q, want %q", got.Error(), want)
+	}
+}
+
+func TestJob_RetryPanics(t *testing.T) {
+	scheduler := NewScheduler()
+	var panics []interface{}
+	scheduler.SetPanicHandler(func(job *Job, r interface{}, stack []byte) { panics = append(panics, r) })
+	var failures []error
+	scheduler.SetErrorHandler(func(job *Job, err error) { failures = append(failures, err) })
+	calls := 0
+	job := scheduler.Every(1).Hour().Retry(2, 0)
+	job.Do(func() {
+		calls++
+		if calls <= 2 {
+			panic("flaky")
+		}
+	})
+
+	scheduler.RunAll()
+	<-scheduler.inflight.idle()
+	if calls != 3 || job.Retries() != 2 {
+		t.Errorf("%d calls and %d retries, want the run retried twice", calls, job.Retries())
+	}
+	if len(panics) != 0 || len(failures) != 0 || job.Panics() != 0 {
+		t.Errorf("panic handler got %v, error handler %v, want none for a run that succeeded on retry", panics, failures)
+	}
+
+	always := scheduler.Every(1).Hour().Retry(1, 0)
+	always.Do(panickingJob)
+	always.RunNow()
+	<-scheduler.inflight.idle()
+	if len(panics) != 1 || always.Panics() != 1 {
+		t.Errorf("panic handler got %v and %d panics counted, want the last panic once", panics, always.Panics())
+	}
+	var exhausted *RetriesExhaustedError
+	if len(failures) != 1 || !errors.As(failures[0], &exhausted) || exhausted.Attempts != 2 {
+		t.Fatalf("error handler got %v, want the retries exhausted", failures)
+	}
+	if want := "retries exhausted after 2 attempts: panic: x"; exhausted.Error() != want {
+		t.Errorf("Error() = %q, want %q", exhausted.Error(), want)
+	}
+}
+
+func TestJob_RetrySingleton(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	called := make(chan struct{}, 10)
+	job := scheduler.Every(1).Hour().SingletonMode().Retry(1, time.Minute)
+	job.Do(func() error {
+		called <- struct{}{}
+		return errors.New("flaky")
+	})
+
+	scheduler.RunAll()
+	<-called
+	fake.BlockUntil(1)
+	// the run waits for its retry, and counts as going
+	scheduler.RunAll()
+	fake.Advance(time.Minute)
+	<-called
+	<-scheduler.inflight.idle()
+	if job.RunCount() != 1 || job.SkippedRuns() != 1 {
+		t.Errorf("%d runs and %d skipped, want the run during the backoff skipped", job.RunCount(), job.SkippedRuns())
+	}
+}
+
+func TestJob_RetryErrors(t *testing.T) {
+	scheduler := NewScheduler()
+	if err := scheduler.Every(1).Hour().Retry(0, time.Second).Do(task); err == nil {
+		t.Error("Retry(0) should fail")
+	}
+	if err := scheduler.Every(1).Hour().Retry(1, -time.Second).Do(task); err == nil {
+		t.Error("Retry() with a negative backoff should fail")
+	}
+}
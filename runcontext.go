@@ -0,0 +1,46 @@
+package gocron
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	runNumberCtx  struct{}
+	runAttemptCtx struct{}
+)
+
+// RunNumber - The number of the run ctx was handed to, counting the job's
+// runs from 1. It is fixed when the run is dispatched, so it matches
+// RunCount at that point and never repeats for the job, e.g. for naming
+// output files "export-%06d.parquet". ok is false outside of a run.
+func RunNumber(ctx context.Context) (n int, ok bool) {
+	n, ok = ctx.Value(runNumberCtx{}).(int)
+	return
+}
+
+// RunAttempt - Which call of its run ctx was handed to, 1 for the first
+// and one more for each retry, see Retry. Retries keep the RunNumber of
+// the run they belong to, so the two name an attempt uniquely, e.g.
+// "export-%06d.%d.parquet". ok is false outside of a run.
+func RunAttempt(ctx context.Context) (n int, ok bool) {
+	n, ok = ctx.Value(runAttemptCtx{}).(int)
+	return
+}
+
+// The context of one call of t, carrying its run number, attempt and
+// idempotency key
+func runContext(ctx context.Context, t jobTask) context.Context {
+	if t.run > 0 {
+		ctx = context.WithValue(ctx, runNumberCtx{}, t.run)
+	}
+	if t.attempt > 0 {
+		ctx = context.WithValue(ctx, runAttemptCtx{}, t.attempt)
+	}
+	sum, err := FingerprintParams(t.params...)
+	if err != nil {
+		return ctx
+	}
+	key := t.name + "@" + t.due.UTC().Format(time.RFC3339Nano) + "/" + sum
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
@@ -0,0 +1,82 @@
+package gocron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunNumber(t *testing.T) {
+	scheduler := NewScheduler()
+	numbers := make(chan int, 3)
+	job := scheduler.Every(1).Hour()
+	job.Do(func(ctx context.Context) {
+		n, _ := RunNumber(ctx)
+		numbers <- n
+	})
+
+	for want := 1; want <= 3; want++ {
+		scheduler.RunAll()
+		if n := <-numbers; n != want {
+			t.Errorf("run %d got RunNumber %d", want, n)
+		}
+	}
+	if _, ok := RunNumber(context.Background()); ok {
+		t.Error("RunNumber outside of a run should not be ok")
+	}
+}
+
+func TestRunNumber_QueuedRun(t *testing.T) {
+	scheduler := NewScheduler()
+	numbers := make(chan int, 2)
+	release := make(chan struct{})
+	job := scheduler.Every(1).Hour().SingletonMode(SingletonQueueOne)
+	job.Do(func(ctx context.Context) {
+		n, _ := RunNumber(ctx)
+		numbers <- n
+		<-release
+	})
+
+	scheduler.RunAll()
+	first := <-numbers
+	scheduler.RunAll() // queued behind the first
+	close(release)
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if second := <-numbers; first != 1 || second != 2 {
+		t.Errorf("got run numbers %d and %d, want 1 and 2", first, second)
+	}
+}
+
+func TestRunAttempt(t *testing.T) {
+	scheduler := NewScheduler()
+	var calls []string
+	job := scheduler.Every(1).Hour().Retry(2, 0)
+	job.Do(func(ctx context.Context) error {
+		n, _ := RunNumber(ctx)
+		attempt, _ := RunAttempt(ctx)
+		calls = append(calls, fmt.Sprintf("%d.%d", n, attempt))
+		if attempt < 2 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		scheduler.RunAll()
+		if err := scheduler.Wait(time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a retry keeps its run's number
+	if got := strings.Join(calls, " "); got != "1.1 1.2 2.1 2.2" {
+		t.Errorf("calls %s, want 1.1 1.2 2.1 2.2", got)
+	}
+	if _, ok := RunAttempt(context.Background()); ok {
+		t.Error("RunAttempt outside of a run should not be ok")
+	}
+}
@@ -0,0 +1,124 @@
+package gocron
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Default deadlines of Run's shutdown
+const (
+	DefaultGracePeriod       = 30 * time.Second
+	DefaultCancelGracePeriod = 5 * time.Second
+)
+
+// RunnerOptions - How Run waits and shuts down, the zero value is usable
+type RunnerOptions struct {
+	// Signals that end the run, SIGINT and SIGTERM when empty. Other
+	// receivers of the signals keep getting them.
+	Signals []os.Signal
+	// NoSignals leaves signals alone, for callers that handle them
+	// themselves and cancel the context given to Run instead
+	NoSignals bool
+
+	// GracePeriod is how long running jobs get to return once the run
+	// ends, DefaultGracePeriod when zero
+	GracePeriod time.Duration
+	// CancelGracePeriod is how long jobs still running after the grace
+	// period get once their context is cancelled, DefaultCancelGracePeriod
+	// when zero
+	CancelGracePeriod time.Duration
+}
+
+// Run - Start the scheduler and block until ctx is done or one of the
+// signals arrives, then shut down: stop dispatching, give running jobs
+// the grace period to return, cancel the context handed to them and wait
+// the cancel grace period. The returned error lists the jobs that were
+// still running at the end, so main can exit non-zero, e.g.
+//
+//	if err := s.Run(context.Background(), gocron.RunnerOptions{}); err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Scheduler) Run(ctx context.Context, opts RunnerOptions) error {
+	if opts.GracePeriod <= 0 {
+		opts.GracePeriod = DefaultGracePeriod
+	}
+	if opts.CancelGracePeriod <= 0 {
+		opts.CancelGracePeriod = DefaultCancelGracePeriod
+	}
+	if !opts.NoSignals {
+		var stop func()
+		ctx, stop = withSignals(ctx, opts.Signals)
+		defer stop()
+	}
+
+	// jobs are cancelled in the second phase, not as soon as ctx is done
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+	s.StartWithContext(jobCtx)
+
+	<-ctx.Done()
+	s.Stop()
+	if s.Wait(opts.GracePeriod) == nil {
+		return nil
+	}
+	cancelJobs()
+	if s.Wait(opts.CancelGracePeriod) == nil {
+		return nil
+	}
+	names := s.inflight.names()
+	return errors.New("shutdown abandoned " + strconv.Itoa(len(names)) + " running jobs: " + strings.Join(names, ", "))
+}
+
+// A context of ctx cancelled when one of sigs arrives, SIGINT and SIGTERM
+// when empty. stop releases the signals.
+func withSignals(ctx context.Context, sigs []os.Signal) (_ context.Context, stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	go func() {
+		select {
+		case <-ch:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(ch)
+		cancel()
+	}
+}
+
+// StartBlocking - Run the scheduler's loop on the calling goroutine until
+// Stop is called or ctx is done, then wait for the runs still going to
+// return, e.g. as the main loop of a worker. Jobs taking a context are
+// passed ctx, as with StartWithContext. On a scheduler started already it
+// waits for the running loop to end instead.
+func (s *Scheduler) StartBlocking(ctx context.Context) {
+	if loop := s.start(ctx, nil); loop != nil {
+		loop()
+	} else {
+		s.mu.RLock()
+		loopDone := s.loopDone
+		s.mu.RUnlock()
+		<-loopDone
+	}
+	<-s.inflight.idle()
+}
+
+// StartBlockingWithSignals - StartBlocking, also ending when one of sigs
+// arrives, SIGINT and SIGTERM when none are given. The signal cancels the
+// context handed to the jobs. Use Run for a shutdown with grace periods.
+func (s *Scheduler) StartBlockingWithSignals(ctx context.Context, sigs ...os.Signal) {
+	ctx, stop := withSignals(ctx, sigs)
+	defer stop()
+	s.StartBlocking(ctx)
+}
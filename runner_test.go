@@ -0,0 +1,165 @@
+package gocron
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var quickShutdown = RunnerOptions{
+	NoSignals:         true,
+	GracePeriod:       100 * time.Millisecond,
+	CancelGracePeriod: 100 * time.Millisecond,
+}
+
+// Run the scheduler until a job sends on started, then cancel
+func runUntilStarted(t *testing.T, s *Scheduler, started chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- s.Run(ctx, quickShutdown) }()
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the job did not start")
+	}
+	cancel()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return")
+	}
+	return nil
+}
+
+func TestScheduler_RunGraceful(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{}, 1)
+	cancelled := make(chan bool, 1)
+	scheduler.EveryDuration(10 * time.Millisecond).SingletonMode().Do(func(ctx context.Context) {
+		started <- struct{}{}
+		time.Sleep(30 * time.Millisecond)
+		cancelled <- ctx.Err() != nil
+	})
+
+	if err := runUntilStarted(t, scheduler, started); err != nil {
+		t.Errorf("Run() = %v, want a clean shutdown", err)
+	}
+	if <-cancelled {
+		t.Error("a job finishing within the grace period saw its context cancelled")
+	}
+}
+
+func TestScheduler_RunCancelsAfterGrace(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{}, 1)
+	scheduler.EveryDuration(10 * time.Millisecond).SingletonMode().Do(func(ctx context.Context) {
+		started <- struct{}{}
+		<-ctx.Done()
+	})
+
+	begin := time.Now()
+	if err := runUntilStarted(t, scheduler, started); err != nil {
+		t.Errorf("Run() = %v, want the job to return once cancelled", err)
+	}
+	if took := time.Since(begin); took < quickShutdown.GracePeriod {
+		t.Errorf("Run returned after %v, before the grace period", took)
+	}
+}
+
+func stuckJob(started, release chan struct{}) {
+	started <- struct{}{}
+	<-release
+}
+
+func TestScheduler_RunAbandoned(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	scheduler.EveryDuration(10*time.Millisecond).SingletonMode().Do(stuckJob, started, release)
+
+	err := runUntilStarted(t, scheduler, started)
+	if err == nil || !strings.Contains(err.Error(), "abandoned 1 running jobs") || !strings.Contains(err.Error(), "stuckJob") {
+		t.Errorf("Run() = %v, want stuckJob reported as abandoned", err)
+	}
+}
+
+func TestScheduler_StartIdempotent(t *testing.T) {
+	scheduler := NewScheduler()
+	stopped := scheduler.Start()
+	if again := scheduler.Start(); again != stopped {
+		t.Error("a second Start should return the running loop's channel")
+	}
+	ctx := context.WithValue(context.Background(), struct{}{}, "other")
+	scheduler.StartWithContext(ctx)
+	if scheduler.context() == ctx {
+		t.Error("StartWithContext on a running scheduler started another loop")
+	}
+	scheduler.Stop()
+
+	scheduler.StartWithContext(ctx)
+	bridge := scheduler.Start()
+	bridge <- true
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Errorf("sending on the channel of a second Start did not stop the loop: %v", err)
+	}
+}
+
+func TestScheduler_StartBlocking(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{}, 1)
+	var finished int32
+	scheduler.EveryDuration(10 * time.Millisecond).SingletonMode().Do(func() {
+		started <- struct{}{}
+		time.Sleep(50 * time.Millisecond)
+		atomic.StoreInt32(&finished, 1)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	returned := make(chan struct{})
+	go func() {
+		scheduler.StartBlocking(ctx)
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(2 * time.Second):
+		t.Fatal("StartBlocking did not return once ctx was cancelled")
+	}
+	if atomic.LoadInt32(&finished) != 1 {
+		t.Error("StartBlocking returned before the run going had returned")
+	}
+}
+
+func TestScheduler_StartBlockingWithSignals(t *testing.T) {
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Skip(err)
+	}
+	scheduler := NewScheduler()
+	returned := make(chan struct{})
+	go func() {
+		scheduler.StartBlockingWithSignals(context.Background(), syscall.SIGHUP)
+		close(returned)
+	}()
+	for scheduler.context() == context.Background() {
+		time.Sleep(time.Millisecond)
+	}
+	if err := self.Signal(syscall.SIGHUP); err != nil {
+		t.Skip("cannot signal the test process:", err)
+	}
+	select {
+	case <-returned:
+	case <-time.After(2 * time.Second):
+		t.Fatal("StartBlockingWithSignals did not return on the signal")
+	}
+}
@@ -0,0 +1,79 @@
+package gocron
+
+import "errors"
+
+// RunMode - Whether a run started by hand moves the job's schedule
+type RunMode int
+
+const (
+	// RunKeepSchedule - Leave the schedule as it is, the job's next run
+	// stays when it was due
+	RunKeepSchedule RunMode = iota
+	// RunReschedule - Count the run as the job's next occurrence, which
+	// moves its next run on by one interval, as RunAll does
+	RunReschedule
+)
+
+// RunNow - Run the job right away the way the scheduler runs it, so that
+// singleton mode, the scheduler's concurrency limit, ordering lanes,
+// LimitRunsTo and shadow mode all apply. By default, or with
+// RunKeepSchedule, its next run stays where it was; LastRun still reports
+// the run.
+func (j *Job) RunNow(m ...RunMode) error {
+	if j.scheduler == nil {
+		return errors.New("RunNow() requires a job added to a scheduler")
+	}
+	if !j.scheduler.runNowMode(j, runMode(m)) {
+		return errors.New("RunNow() on a job that is not scheduled")
+	}
+	return nil
+}
+
+// RunByTag - Run every job tagged with tag right away, as Job.RunNow
+// does, returning an error if there is none
+func (s *Scheduler) RunByTag(tag string, m ...RunMode) error {
+	mode := runMode(m)
+	ran := false
+	for _, job := range s.snapshot() {
+		if job.tagged(tag) && s.runNowMode(job, mode) {
+			ran = true
+		}
+	}
+	if !ran {
+		return errors.New("no job tagged " + tag)
+	}
+	return nil
+}
+
+// RunAllKeepSchedule - Run all jobs now like RunAll, leaving their
+// schedules as they are, e.g. for a smoke test of a live scheduler
+func (s *Scheduler) RunAllKeepSchedule() {
+	for _, job := range s.snapshot() {
+		s.runNowMode(job, RunKeepSchedule)
+	}
+}
+
+// The mode of an optional RunMode argument
+func runMode(m []RunMode) RunMode {
+	if len(m) == 0 {
+		return RunKeepSchedule
+	}
+	return m[0]
+}
+
+// Run the job now in mode m, reporting whether it is scheduled
+func (s *Scheduler) runNowMode(j *Job, m RunMode) bool {
+	if m == RunReschedule {
+		return s.runNow(j)
+	}
+	// a run off the schedule is due when it starts
+	j.mu.Lock()
+	if j.jobFunc == "" || j.removed {
+		j.mu.Unlock()
+		return false
+	}
+	due := j.now()
+	j.mu.Unlock()
+	s.runJob(j, due)
+	return true
+}
@@ -0,0 +1,113 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestJob_RunNow(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	job := scheduler.Every(1).Hour()
+	job.Do(task)
+	next := job.NextRun()
+
+	fake.Advance(10 * time.Minute)
+	if err := job.RunNow(); err != nil {
+		t.Fatal(err)
+	}
+	<-scheduler.inflight.idle()
+	if job.RunCount() != 1 || !job.LastRun().Equal(fake.Now()) {
+		t.Errorf("%d runs, last at %v, want one now", job.RunCount(), job.LastRun())
+	}
+	if got := job.NextRun(); !got.Equal(next) {
+		t.Errorf("NextRun() = %v after RunNow, want it left at %v", got, next)
+	}
+
+	if err := job.RunNow(RunReschedule); err != nil {
+		t.Fatal(err)
+	}
+	<-scheduler.inflight.idle()
+	if got := job.NextRun(); !got.Equal(next.Add(time.Hour)) {
+		t.Errorf("NextRun() = %v after RunNow(RunReschedule), want %v", got, next.Add(time.Hour))
+	}
+}
+
+func TestJob_RunNowSingleton(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	job := scheduler.Every(1).Hour().SingletonMode()
+	job.Do(stuckJob, started, release)
+
+	job.RunNow()
+	<-started
+	job.RunNow()
+	close(release)
+	<-scheduler.inflight.idle()
+	if job.RunCount() != 1 || job.SkippedRuns() != 1 {
+		t.Errorf("%d runs and %d skipped, want the second RunNow skipped", job.RunCount(), job.SkippedRuns())
+	}
+}
+
+func TestJob_RunNowErrors(t *testing.T) {
+	if err := NewJob(1).RunNow(); err == nil {
+		t.Error("RunNow() on a job without a scheduler should fail")
+	}
+	scheduler := NewScheduler()
+	if err := scheduler.Every(1).Hour().RunNow(); err == nil {
+		t.Error("RunNow() on a job without Do should fail")
+	}
+	job := scheduler.Every(1).Hour()
+	job.Do(task)
+	job.RemoveSelf()
+	if err := job.RunNow(RunReschedule); err == nil {
+		t.Error("RunNow() on a removed job should fail")
+	}
+}
+
+func TestScheduler_RunByTag(t *testing.T) {
+	scheduler := NewScheduler()
+	export := scheduler.Every(1).Day().At("02:00").Tag("nightly")
+	export.Do(task)
+	report := scheduler.Every(1).Day().At("03:00").Tag("nightly")
+	report.Do(task)
+	other := scheduler.Every(1).Day().Tag("hourly")
+	other.Do(task)
+	next := export.NextRun()
+
+	if err := scheduler.RunByTag("nightly"); err != nil {
+		t.Fatal(err)
+	}
+	<-scheduler.inflight.idle()
+	if export.RunCount() != 1 || report.RunCount() != 1 || other.RunCount() != 0 {
+		t.Errorf("run counts %d, %d, %d, want the two nightly jobs run", export.RunCount(), report.RunCount(), other.RunCount())
+	}
+	if !export.NextRun().Equal(next) {
+		t.Error("RunByTag moved the schedule")
+	}
+	if err := scheduler.RunByTag("weekly"); err == nil {
+		t.Error("RunByTag() with no such job should fail")
+	}
+}
+
+func TestScheduler_RunAllKeepSchedule(t *testing.T) {
+	scheduler := NewScheduler()
+	hourly := scheduler.Every(1).Hour()
+	hourly.Do(task)
+	daily := scheduler.Every(1).Day()
+	daily.Do(task)
+	nexts := []time.Time{hourly.NextRun(), daily.NextRun()}
+
+	scheduler.RunAllKeepSchedule()
+	scheduler.RunAllWithDelay(0, RunKeepSchedule)
+	<-scheduler.inflight.idle()
+	for i, job := range []*Job{hourly, daily} {
+		if job.RunCount() != 2 || !job.NextRun().Equal(nexts[i]) {
+			t.Errorf("job %d ran %d times, next at %v, want 2 runs and %v", i, job.RunCount(), job.NextRun(), nexts[i])
+		}
+	}
+}
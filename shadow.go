@@ -0,0 +1,80 @@
+package gocron
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// WouldHaveRun - A run a shadow scheduler skipped, see SetShadowMode
+type WouldHaveRun struct {
+	Job *Job
+	// ScheduledAt is the nextRun the job was due at
+	ScheduledAt time.Time
+	// Params fingerprints the params the job would have been called with,
+	// see FingerprintParams. It is empty for params that cannot be
+	// fingerprinted.
+	Params string
+}
+
+// SetShadowMode - Run the scheduler without calling any job function.
+// Due jobs go through dispatch as usual: they are rescheduled, count
+// towards RunCount and LimitRunsTo, wait their turn in SingletonMode, on
+// their lane and under the concurrency limit, and their event listeners
+// are called. Only the call itself is replaced by passing a WouldHaveRun
+// to the function set with SetShadowRecorder, so a shadow scheduler can be
+// diffed against a live one. The monitor counts such runs as JobShadow
+// and listeners tell them apart with InShadowRun. It can be toggled while
+// the scheduler runs and applies to the following dispatches.
+func (s *Scheduler) SetShadowMode(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&s.shadow, v)
+}
+
+// SetShadowRecorder - Set the function receiving the runs skipped in shadow mode
+func (s *Scheduler) SetShadowRecorder(record func(WouldHaveRun)) {
+	s.shadowRecord.Store(record)
+}
+
+// InShadowRun - Whether a run of the job going now is a shadow run, e.g.
+// for event listeners labelling their metrics, see SetShadowMode
+func (j *Job) InShadowRun() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.shadowRuns > 0
+}
+
+// Whether runs dispatched now are shadow runs, s may be nil for jobs made
+// with NewJob
+func (s *Scheduler) shadowing() bool {
+	return s != nil && atomic.LoadInt32(&s.shadow) != 0
+}
+
+// Record the run of the occurrence due at due, with params, instead of
+// calling the job
+func (s *Scheduler) shadowRun(j *Job, due time.Time, params []interface{}) {
+	if record, _ := s.shadowRecord.Load().(func(WouldHaveRun)); record != nil {
+		sum, _ := FingerprintParams(params...)
+		record(WouldHaveRun{
+			Job:         j,
+			ScheduledAt: due,
+			Params:      sum,
+		})
+	}
+}
+
+// Finish a shadow run: it has no result to handle, only the listeners and
+// the monitor hear of it
+func (j *Job) shadowFinished(r runResult) {
+	j.runReturned(r.took)
+	if m, name := j.monitor(); m != nil {
+		m.RecordJobTiming(name, r.began, r.began.Add(r.took))
+		m.IncrementJob(name, JobShadow)
+	}
+	j.mu.Lock()
+	j.active--
+	j.shadowRuns--
+	j.mu.Unlock()
+}
@@ -0,0 +1,104 @@
+package gocron
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestScheduler_ShadowMode(t *testing.T) {
+	var mu sync.Mutex
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	live, shadow := NewScheduler(), NewScheduler()
+	liveClock, shadowClock := clock.NewFake(start), clock.NewFake(start)
+	live.SetClock(liveClock)
+	shadow.SetClock(shadowClock)
+	shadow.SetShadowMode(true)
+	monitor := NewMemoryMonitor()
+	shadow.SetMonitor(monitor)
+	labelled := 0
+	shadow.SetEventListeners(nil, func(j *Job, _ time.Duration) {
+		if j.InShadowRun() {
+			mu.Lock()
+			labelled++
+			mu.Unlock()
+		}
+	})
+
+	var ran, recorded []string
+	shadow.SetShadowRecorder(func(r WouldHaveRun) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, r.Job.GetName()+"@"+r.ScheduledAt.Format("15:04:05"))
+		if sum, _ := FingerprintParams(r.Job.GetName()); r.Params != sum {
+			t.Errorf("params fingerprint %s of %s does not match", r.Params, r.Job.GetName())
+		}
+	})
+	for _, s := range []*Scheduler{live, shadow} {
+		c := liveClock
+		if s == shadow {
+			c = shadowClock
+		}
+		record := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			// runs start just after they are due
+			ran = append(ran, name+"@"+c.Now().Truncate(time.Second).Format("15:04:05"))
+		}
+		s.ChangeLoc(time.UTC)
+		s.Every(1).Minute().Name("minutely").Do(record, "minutely")
+		s.Every(7).Minutes().Name("every7").Do(record, "every7")
+		s.Every(1).Hour().Name("hourly").Do(record, "hourly")
+		s.Every(1).Day().At("12:30").Name("daily").Do(record, "daily")
+		s.Every(10).Minutes().Name("limited").LimitRunsTo(3).Do(record, "limited")
+	}
+
+	for _, s := range []*Scheduler{live, shadow} {
+		if _, err := s.FastForward(context.Background(), start.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// every run has returned
+	if want := 60 + 8 + 1 + 1 + 3; len(ran) != want {
+		t.Fatalf("the live scheduler ran %d times over the hour, want %d", len(ran), want)
+	}
+	sort.Strings(ran)
+	sort.Strings(recorded)
+	if len(recorded) != len(ran) {
+		t.Fatalf("got %d WouldHaveRun records, want one per live run, %d", len(recorded), len(ran))
+	}
+	for i := range ran {
+		if recorded[i] != ran[i] {
+			t.Fatalf("shadow recorded %s where the live scheduler ran %s", recorded[i], ran[i])
+		}
+	}
+	liveJobs, shadowJobs := live.Jobs(), shadow.Jobs()
+	if len(shadowJobs) != len(liveJobs) {
+		t.Fatalf("shadow has %d jobs left, live %d", len(shadowJobs), len(liveJobs))
+	}
+	for i, job := range shadowJobs {
+		if !job.NextRun().Equal(liveJobs[i].NextRun()) || job.RunCount() != liveJobs[i].RunCount() {
+			t.Errorf("shadow %s next at %v after %d runs, live at %v after %d", job.GetName(),
+				job.NextRun(), job.RunCount(), liveJobs[i].NextRun(), liveJobs[i].RunCount())
+		}
+	}
+	if got := monitor.Stats()["limited"]; got.Shadow != 3 || got.Success != 0 {
+		t.Errorf("monitor saw %+v of the limited job, want 3 shadow runs", got)
+	}
+	if labelled != len(recorded) {
+		t.Errorf("%d listener calls were labelled as shadow runs, want %d", labelled, len(recorded))
+	}
+
+	shadow.SetShadowMode(false)
+	if _, err := shadow.FastForward(context.Background(), start.Add(time.Hour+time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) != 74 || ran[73] != "minutely@13:01:00" {
+		t.Errorf("got runs %v after leaving shadow mode, want the minutely job called", ran[73:])
+	}
+}
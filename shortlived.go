@@ -0,0 +1,36 @@
+package gocron
+
+// RunPendingAndWait - Run the jobs that are due, like RunPending, and block
+// until every run has returned. It needs no Start, so a scheduler can be
+// built, run for one pass and thrown away, e.g.
+//
+//	s := gocron.NewScheduler()
+//	defer s.Close()
+//	s.Every(1).Hour().StartImmediately().Do(collect)
+//	s.RunPendingAndWait()
+//
+// Runs already going when it is called are waited for as well.
+func (s *Scheduler) RunPendingAndWait() {
+	s.RunPending()
+	<-s.inflight.idle()
+}
+
+// Close - Stop the scheduler and drop its jobs and handlers, without
+// waiting for runs still going. The scheduler holds nothing afterwards
+// and can be discarded; calling Close again does nothing.
+func (s *Scheduler) Close() {
+	s.Stop()
+	s.mu.Lock()
+	s.jobs = nil
+	s.ctx = nil
+	s.errorHandler = nil
+	s.sloHandler = nil
+	s.panicHandler = nil
+	s.tzHandler = nil
+	s.beforeRun = nil
+	s.afterRun = nil
+	s.mu.Unlock()
+	s.laneMu.Lock()
+	s.lanes = nil
+	s.laneMu.Unlock()
+}
@@ -0,0 +1,70 @@
+package gocron
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// One short-lived scheduler: five jobs, one pass, closed
+func onePass(ran *int32) {
+	s := NewScheduler()
+	defer s.Close()
+	for i := 0; i < 5; i++ {
+		s.Every(1).Hour().StartImmediately().Do(func() { atomic.AddInt32(ran, 1) })
+	}
+	s.RunPendingAndWait()
+}
+
+func TestScheduler_RunPendingAndWait(t *testing.T) {
+	var ran int32
+	onePass(&ran)
+	if ran != 5 {
+		t.Errorf("%d runs returned before RunPendingAndWait did, want 5", ran)
+	}
+
+	s := NewScheduler()
+	job := s.Every(1).Hour()
+	job.Do(task)
+	s.Close()
+	s.Close()
+	if len(s.Jobs()) != 0 {
+		t.Error("Close kept the jobs")
+	}
+	s.RunPendingAndWait()
+	if job.RunCount() != 0 {
+		t.Error("a closed scheduler ran a job")
+	}
+}
+
+func TestScheduler_CreateDiscard(t *testing.T) {
+	if testing.Short() {
+		t.Skip("creates thousands of schedulers")
+	}
+	var ran int32
+	onePass(&ran)
+	before := runtime.NumGoroutine()
+	for i := 0; i < 2000; i++ {
+		onePass(&ran)
+	}
+	if ran != 5*2001 {
+		t.Errorf("got %d runs, want %d", ran, 5*2001)
+	}
+	// the run goroutines may still be on their way out after signalling
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%d goroutines after the loop, %d before", n, before)
+	}
+}
+
+func BenchmarkScheduler_OnePass(b *testing.B) {
+	b.ReportAllocs()
+	var ran int32
+	for i := 0; i < b.N; i++ {
+		onePass(&ran)
+	}
+}
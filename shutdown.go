@@ -0,0 +1,189 @@
+package gocron
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// Whether a job function takes a context.Context first
+func takesContext(t reflect.Type) bool {
+	return t.NumIn() > 0 && t.In(0) == contextType
+}
+
+// The context handed to jobs, s may be nil for jobs made with NewJob
+func (s *Scheduler) context() context.Context {
+	if s != nil {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		if s.ctx != nil {
+			return s.ctx
+		}
+	}
+	return context.Background()
+}
+
+// Stop - Stop the loop started by Start or StartWithContext, so that no
+// new runs are dispatched. Runs already started keep going, use Wait for
+// them to finish. Starting the scheduler again resumes interval jobs as
+// set with SetResumePolicy.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	quit, loopDone := s.quit, s.loopDone
+	s.quit = nil
+	s.mu.Unlock()
+	if quit == nil {
+		return
+	}
+	close(quit)
+	// let a dispatch pass in progress finish starting its runs
+	<-loopDone
+}
+
+// Wait - Block until every run has returned, or return an error once
+// timeout elapses. Call it after Stop or cancelling the context given to
+// StartWithContext, so that no new runs start meanwhile.
+func (s *Scheduler) Wait(timeout time.Duration) error {
+	s.mu.RLock()
+	loopDone := s.loopDone
+	s.mu.RUnlock()
+
+	deadline := time.After(timeout)
+	if loopDone != nil {
+		select {
+		case <-loopDone:
+		case <-deadline:
+			return errors.New("timed out waiting for the scheduler to stop")
+		}
+	}
+	select {
+	case <-s.inflight.idle():
+		return nil
+	case <-deadline:
+		return errors.New("timed out waiting for " + strconv.Itoa(s.inflight.count()) + " running jobs")
+	}
+}
+
+// inflight counts runs that have not returned
+type inflight struct {
+	mu sync.Mutex
+	n  int
+	// runs per job function
+	byName  map[string]int
+	waiters []chan struct{}
+	// what runs wait for on the clock, see FastForward
+	deadlines map[*deadline]struct{}
+}
+
+// deadline is a time a run waits for on its clock, the end of a retry
+// backoff or its timeout
+type deadline struct {
+	at      time.Time
+	backoff bool
+}
+
+// A shared tracker for jobs made with NewJob that no scheduler waits on
+var detached inflight
+
+func (s *Scheduler) tracker() *inflight {
+	if s == nil {
+		return &detached
+	}
+	return &s.inflight
+}
+
+func (t *inflight) add(name string) {
+	t.mu.Lock()
+	t.n++
+	if t.byName == nil {
+		t.byName = make(map[string]int)
+	}
+	t.byName[name]++
+	t.mu.Unlock()
+}
+
+func (t *inflight) done(name string) {
+	t.mu.Lock()
+	t.n--
+	if t.byName[name]--; t.byName[name] == 0 {
+		delete(t.byName, name)
+	}
+	if t.n == 0 {
+		for _, w := range t.waiters {
+			close(w)
+		}
+		t.waiters = nil
+	}
+	t.mu.Unlock()
+}
+
+// Note that a run waits on its clock until at, until the returned func
+// is called
+func (t *inflight) await(at time.Time, backoff bool) func() {
+	d := &deadline{at: at, backoff: backoff}
+	t.mu.Lock()
+	if t.deadlines == nil {
+		t.deadlines = make(map[*deadline]struct{})
+	}
+	t.deadlines[d] = struct{}{}
+	t.mu.Unlock()
+	return func() {
+		t.mu.Lock()
+		delete(t.deadlines, d)
+		t.mu.Unlock()
+	}
+}
+
+// The earliest time a run waits for, and whether every run is waiting
+// out a backoff so that nothing but the clock can move them on
+func (t *inflight) nextDeadline() (at time.Time, stalled bool, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	backoffs := 0
+	for d := range t.deadlines {
+		if d.backoff {
+			backoffs++
+		}
+		if !ok || d.at.Before(at) {
+			at, ok = d.at, true
+		}
+	}
+	return at, ok && backoffs >= t.n, ok
+}
+
+func (t *inflight) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.n
+}
+
+// names returns the functions of the runs left, sorted
+func (t *inflight) names() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := make([]string, 0, len(t.byName))
+	for name := range t.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// idle returns a channel closed once no runs are left
+func (t *inflight) idle() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w := make(chan struct{})
+	if t.n == 0 {
+		close(w)
+	} else {
+		t.waiters = append(t.waiters, w)
+	}
+	return w
+}
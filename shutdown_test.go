@@ -0,0 +1,70 @@
+package gocron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScheduler_StartWithContextWait(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	job := scheduler.Every(1).Hour()
+	job.Do(func() {
+		close(started)
+		time.Sleep(time.Second)
+		close(finished)
+	})
+	job.nextRun = time.Now().Add(-time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.StartWithContext(ctx)
+	<-started
+	cancel()
+
+	if err := scheduler.Wait(100 * time.Millisecond); err == nil {
+		t.Error("Wait should time out while the job is still running")
+	}
+	if err := scheduler.Wait(3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Error("Wait returned before the job completed")
+	}
+}
+
+func TestScheduler_StopPassesContext(t *testing.T) {
+	scheduler := NewScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	got := make(chan string, 1)
+	job := scheduler.Every(1).Hour()
+	job.Do(func(ctx context.Context, name string) {
+		close(started)
+		<-ctx.Done()
+		got <- name
+	}, "sync")
+	job.nextRun = time.Now().Add(-time.Second)
+
+	scheduler.StartWithContext(ctx)
+	<-started
+	scheduler.Stop()
+	scheduler.Stop()
+	cancel()
+
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if name := <-got; name != "sync" {
+		t.Errorf("job got param %q, want sync after the context", name)
+	}
+}
+
+func TestScheduler_WaitIdle(t *testing.T) {
+	if err := NewScheduler().Wait(time.Millisecond); err != nil {
+		t.Errorf("Wait on an idle scheduler = %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package gocron
+
+import "time"
+
+// SingletonPolicy - What a singleton job does when it comes due while its
+// previous run is still going
+type SingletonPolicy int
+
+const (
+	// SingletonSkip drops the run
+	SingletonSkip SingletonPolicy = iota
+	// SingletonQueueOne keeps one run pending and starts it as soon as the
+	// previous run returns, further runs are dropped meanwhile
+	SingletonQueueOne
+)
+
+// SingletonMode - Never run the job concurrently with itself, e.g.
+// s.Every(1).Minute().SingletonMode().Do(syncDatabase)
+// The policy defaults to SingletonSkip. Dropped runs are counted by
+// SkippedRuns.
+func (j *Job) SingletonMode(policy ...SingletonPolicy) *Job {
+	if !j.building("SingletonMode") {
+		return j
+	}
+	p := SingletonSkip
+	if len(policy) > 0 {
+		p = policy[0]
+	}
+	j.mu.Lock()
+	j.singleton = true
+	j.singletonPolicy = p
+	j.mu.Unlock()
+	return j
+}
+
+// SkippedRuns - How many runs of a singleton job were dropped because the
+// previous one was still going, plus those dropped because the scheduler
+// was at its limit of concurrent runs with LimitReschedule or because
+// their lane was full with LaneSkipOldest
+func (j *Job) SkippedRuns() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.skippedRuns
+}
+
+// Whether a run now would be dropped, requires j.mu held
+func (j *Job) overlapping() bool {
+	return j.singleton && j.running &&
+		(j.singletonPolicy == SingletonSkip || j.pending)
+}
+
+// Gate a run of the occurrence due at due for singleton jobs. start
+// reports whether the run should start now; when a run is going it is
+// either queued, which still counts as accepted, or dropped. Requires
+// j.mu held.
+func (j *Job) admit(due time.Time) (start, accepted bool) {
+	if !j.singleton {
+		return true, true
+	}
+	switch {
+	case !j.running:
+		j.running = true
+		return true, true
+	case j.overlapping():
+		j.skippedRuns++
+		return false, false
+	}
+	j.pending = true
+	j.pendingDue = due
+	return false, true
+}
+
+// Note that a singleton run returned, reporting the due time of the queued
+// run to start next, if any. Requires j.mu held.
+func (j *Job) release() (due time.Time, next bool) {
+	if !j.singleton {
+		return time.Time{}, false
+	}
+	if j.pending {
+		j.pending = false
+		return j.pendingDue, true
+	}
+	j.running = false
+	return time.Time{}, false
+}
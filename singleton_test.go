@@ -0,0 +1,96 @@
+package gocron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJob_SingletonMode(t *testing.T) {
+	scheduler := NewScheduler()
+	var mu sync.Mutex
+	running, maxRunning, runs := 0, 0, 0
+	job := scheduler.EveryDuration(50 * time.Millisecond).SingletonMode()
+	job.Do(func() {
+		mu.Lock()
+		running++
+		runs++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(120 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	stopped := scheduler.Start()
+	time.Sleep(600 * time.Millisecond)
+	stopped <- true
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxRunning != 1 {
+		t.Errorf("up to %d runs overlapped, want 1", maxRunning)
+	}
+	if runs < 2 {
+		t.Errorf("got %d runs, want the job to keep running", runs)
+	}
+	if job.SkippedRuns() == 0 {
+		t.Error("no runs were counted as skipped")
+	}
+	skipped := 0
+	for _, pass := range scheduler.RecentDispatchPasses(DispatchLogSize) {
+		for _, s := range pass.Skipped {
+			if s.Reason == SkipRunning {
+				skipped++
+			}
+		}
+	}
+	if skipped == 0 {
+		t.Error("dispatch passes did not record the skipped runs")
+	}
+}
+
+func TestJob_SingletonQueueOne(t *testing.T) {
+	scheduler := NewScheduler()
+	release := make(chan struct{})
+	started := make(chan struct{}, 3)
+	job := scheduler.Every(1).Hour().SingletonMode(SingletonQueueOne)
+	job.Do(func() {
+		started <- struct{}{}
+		<-release
+	})
+
+	scheduler.RunAll()
+	<-started
+	scheduler.RunAll() // queued
+	scheduler.RunAll() // dropped
+	if n := job.SkippedRuns(); n != 1 {
+		t.Errorf("SkippedRuns() = %d, want 1", n)
+	}
+	close(release)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the queued run did not start after the first returned")
+	}
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if len(started) != 0 {
+		t.Error("the dropped run started")
+	}
+
+	// once idle the job runs again right away
+	scheduler.RunAll()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the job did not run again once idle")
+	}
+}
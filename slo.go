@@ -0,0 +1,250 @@
+package gocron
+
+import (
+	"errors"
+	"time"
+)
+
+// SLOSpec - Service level objectives for a job, a zero field leaves its
+// clause out
+type SLOSpec struct {
+	// a run takes longer than this
+	MaxDuration time.Duration
+	// more runs than this fail in a row
+	MaxConsecutiveFailures int
+	// an occurrence has not completed successfully this long after it was due
+	MustCompleteWithin time.Duration
+}
+
+// SLOClause - The part of an SLOSpec an event is about
+type SLOClause string
+
+const (
+	// SLOMaxDuration is SLOSpec.MaxDuration
+	SLOMaxDuration SLOClause = "max_duration"
+	// SLOMaxConsecutiveFailures is SLOSpec.MaxConsecutiveFailures
+	SLOMaxConsecutiveFailures SLOClause = "max_consecutive_failures"
+	// SLOMustCompleteWithin is SLOSpec.MustCompleteWithin
+	SLOMustCompleteWithin SLOClause = "must_complete_within"
+)
+
+// SLOEventType - Whether a clause started or stopped being violated
+type SLOEventType int
+
+const (
+	// SLOViolated is sent when a clause starts being violated
+	SLOViolated SLOEventType = iota
+	// SLORecovered is sent when a violated clause holds again
+	SLORecovered
+)
+
+func (t SLOEventType) String() string {
+	if t == SLORecovered {
+		return "recovered"
+	}
+	return "violated"
+}
+
+// SLOEvent - A clause of a job's SLO changing state
+type SLOEvent struct {
+	Job    *Job
+	Type   SLOEventType
+	Clause SLOClause
+	At     time.Time
+	// the last run's duration for SLOMaxDuration, how far past its
+	// deadline the oldest open occurrence is for SLOMustCompleteWithin
+	Duration time.Duration
+	// the current failure streak
+	Failures int
+}
+
+// SLOStatus - The current state of a job's SLO
+type SLOStatus struct {
+	Spec                SLOSpec
+	Violated            []SLOClause
+	LastDuration        time.Duration
+	ConsecutiveFailures int
+}
+
+// sloState tracks a job's SLO, guarded by the job's mu
+type sloState struct {
+	spec     SLOSpec
+	violated map[SLOClause]bool
+
+	lastDuration time.Duration
+	failures     int
+	// due time of the oldest dispatched occurrence that has not
+	// completed successfully, zero if there is none
+	open time.Time
+}
+
+var sloClauses = []SLOClause{SLOMaxDuration, SLOMaxConsecutiveFailures, SLOMustCompleteWithin}
+
+// SLO - Declare objectives for the job. They are evaluated after every run,
+// and the MustCompleteWithin clause also by the loop of a started
+// scheduler, which wakes up for its deadlines. Each change of a clause is reported once to the scheduler's
+// SLO handler, see SetSLOHandler.
+func (j *Job) SLO(spec SLOSpec) *Job {
+	if spec.MaxDuration < 0 || spec.MaxConsecutiveFailures < 0 || spec.MustCompleteWithin < 0 {
+		j.setErr(errors.New("SLO limits must not be negative"))
+		return j
+	}
+	j.mu.Lock()
+	j.slo = &sloState{spec: spec, violated: make(map[SLOClause]bool)}
+	j.mu.Unlock()
+	return j
+}
+
+// SLOStatus - The state of the job's SLO, ok is false if it has none
+func (j *Job) SLOStatus() (status SLOStatus, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.slo == nil {
+		return SLOStatus{}, false
+	}
+	return SLOStatus{
+		Spec:                j.slo.spec,
+		Violated:            j.slo.violations(),
+		LastDuration:        j.slo.lastDuration,
+		ConsecutiveFailures: j.slo.failures,
+	}, true
+}
+
+// SetSLOHandler - Call fn whenever a clause of a job's SLO is violated or
+// recovers. fn is called from the job's goroutine after a run, or from the
+// scheduler loop for the MustCompleteWithin clause.
+func (s *Scheduler) SetSLOHandler(fn func(SLOEvent)) {
+	s.mu.Lock()
+	s.sloHandler = fn
+	s.mu.Unlock()
+}
+
+// SLOViolations - The number of jobs with at least one violated SLO clause
+func (s *Scheduler) SLOViolations() int {
+	n := 0
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		if job.slo != nil && len(job.slo.violations()) > 0 {
+			n++
+		}
+		job.mu.Unlock()
+	}
+	return n
+}
+
+// The violated clauses in a fixed order
+func (st *sloState) violations() []SLOClause {
+	var out []SLOClause
+	for _, c := range sloClauses {
+		if st.violated[c] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Record the transition of clause c to bad, if it is one
+func (st *sloState) set(events []SLOEvent, c SLOClause, bad bool, now time.Time, d time.Duration) []SLOEvent {
+	if st.violated[c] == bad {
+		return events
+	}
+	st.violated[c] = bad
+	e := SLOEvent{Clause: c, Type: SLORecovered, At: now, Duration: d, Failures: st.failures}
+	if bad {
+		e.Type = SLOViolated
+	}
+	return append(events, e)
+}
+
+// Check the completion window at now, next is the job's next run
+func (st *sloState) checkWindow(events []SLOEvent, now, next time.Time) []SLOEvent {
+	deadline, ok := st.deadline(next)
+	if !ok {
+		return events
+	}
+	late := now.Sub(deadline)
+	return st.set(events, SLOMustCompleteWithin, late > 0, now, late)
+}
+
+// When the oldest open occurrence misses its completion window, next is
+// the job's next run. ok is false without a MustCompleteWithin clause.
+func (st *sloState) deadline(next time.Time) (deadline time.Time, ok bool) {
+	w := st.spec.MustCompleteWithin
+	if w == 0 {
+		return time.Time{}, false
+	}
+	deadline = st.open
+	if deadline.IsZero() {
+		deadline = next
+	}
+	return deadline.Add(w), true
+}
+
+// Note the dispatch of the occurrence due at due, requires j.mu held
+func (j *Job) sloDispatched(due time.Time) {
+	if j.slo != nil && j.slo.open.IsZero() {
+		j.slo.open = due
+	}
+}
+
+// Evaluate the SLO after the run of the occurrence due at due finished
+// with err after d
+func (j *Job) sloFinished(due time.Time, d time.Duration, err error) {
+	j.mu.Lock()
+	now := j.now()
+	st := j.slo
+	if st == nil {
+		j.mu.Unlock()
+		return
+	}
+	var events []SLOEvent
+	st.lastDuration = d
+	if max := st.spec.MaxDuration; max > 0 {
+		events = st.set(events, SLOMaxDuration, d > max, now, d)
+	}
+	if err != nil {
+		st.failures++
+	} else {
+		st.failures = 0
+	}
+	if max := st.spec.MaxConsecutiveFailures; max > 0 {
+		events = st.set(events, SLOMaxConsecutiveFailures, st.failures > max, now, d)
+	}
+	// a late completion is still a violation, even if no tick saw it
+	events = st.checkWindow(events, now, j.nextRun)
+	if err == nil && !st.open.IsZero() && !st.open.After(due) {
+		st.open = time.Time{}
+		events = st.checkWindow(events, now, j.nextRun)
+	}
+	j.mu.Unlock()
+	j.emitSLO(events)
+}
+
+// Evaluate the completion window of every job with an SLO
+func (s *Scheduler) checkSLOs(now time.Time) {
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		var events []SLOEvent
+		if job.slo != nil && job.jobFunc != "" && !job.paused {
+			events = job.slo.checkWindow(nil, now, job.nextRun)
+		}
+		job.mu.Unlock()
+		job.emitSLO(events)
+	}
+}
+
+func (j *Job) emitSLO(events []SLOEvent) {
+	if len(events) == 0 || j.scheduler == nil {
+		return
+	}
+	j.scheduler.mu.RLock()
+	handler := j.scheduler.sloHandler
+	j.scheduler.mu.RUnlock()
+	if handler == nil {
+		return
+	}
+	for _, e := range events {
+		e.Job = j
+		handler(e)
+	}
+}
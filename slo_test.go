@@ -0,0 +1,145 @@
+package gocron
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type sloRecorder struct {
+	mu     sync.Mutex
+	events []SLOEvent
+}
+
+func (r *sloRecorder) add(e SLOEvent) {
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+func (r *sloRecorder) take() []SLOEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := r.events
+	r.events = nil
+	return events
+}
+
+func newSLOScheduler() (*Scheduler, *sloRecorder) {
+	scheduler := NewScheduler()
+	rec := &sloRecorder{}
+	scheduler.SetSLOHandler(rec.add)
+	return scheduler, rec
+}
+
+// run every job once and wait for the runs to finish
+func runAllAndWait(t *testing.T, s *Scheduler) {
+	s.RunAll()
+	if err := s.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectSLOEvents(t *testing.T, rec *sloRecorder, want ...SLOEventType) []SLOEvent {
+	t.Helper()
+	got := rec.take()
+	if len(got) != len(want) {
+		t.Fatalf("got %d events %v, want %v", len(got), got, want)
+	}
+	for i := range want {
+		if got[i].Type != want[i] {
+			t.Errorf("event %d is %v, want %v", i, got[i].Type, want[i])
+		}
+	}
+	return got
+}
+
+func TestJob_SLOMaxDuration(t *testing.T) {
+	scheduler, rec := newSLOScheduler()
+	var mu sync.Mutex
+	delay := 30 * time.Millisecond
+	job := scheduler.Every(1).Hour().SLO(SLOSpec{MaxDuration: 10 * time.Millisecond})
+	job.Do(func() {
+		mu.Lock()
+		d := delay
+		mu.Unlock()
+		time.Sleep(d)
+	})
+
+	runAllAndWait(t, scheduler)
+	events := expectSLOEvents(t, rec, SLOViolated)
+	if e := events[0]; e.Clause != SLOMaxDuration || e.Job != job || e.Duration < delay {
+		t.Errorf("got %+v, want a max duration violation of at least %v", e, delay)
+	}
+	runAllAndWait(t, scheduler)
+	expectSLOEvents(t, rec)
+	if n := scheduler.SLOViolations(); n != 1 {
+		t.Errorf("SLOViolations() = %d, want 1", n)
+	}
+
+	mu.Lock()
+	delay = 0
+	mu.Unlock()
+	runAllAndWait(t, scheduler)
+	expectSLOEvents(t, rec, SLORecovered)
+	if status, _ := job.SLOStatus(); len(status.Violated) != 0 {
+		t.Errorf("still violated: %v", status.Violated)
+	}
+}
+
+func TestJob_SLOMaxConsecutiveFailures(t *testing.T) {
+	scheduler, rec := newSLOScheduler()
+	var mu sync.Mutex
+	var fail error = errors.New("boom")
+	job := scheduler.Every(1).Hour().SLO(SLOSpec{MaxConsecutiveFailures: 2})
+	job.Do(func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return fail
+	})
+
+	runAllAndWait(t, scheduler)
+	runAllAndWait(t, scheduler)
+	expectSLOEvents(t, rec)
+	runAllAndWait(t, scheduler)
+	events := expectSLOEvents(t, rec, SLOViolated)
+	if e := events[0]; e.Clause != SLOMaxConsecutiveFailures || e.Failures != 3 {
+		t.Errorf("got %+v, want a failure streak violation at 3", e)
+	}
+	runAllAndWait(t, scheduler)
+	expectSLOEvents(t, rec)
+	if status, _ := job.SLOStatus(); status.ConsecutiveFailures != 4 {
+		t.Errorf("ConsecutiveFailures = %d, want 4", status.ConsecutiveFailures)
+	}
+
+	mu.Lock()
+	fail = nil
+	mu.Unlock()
+	runAllAndWait(t, scheduler)
+	expectSLOEvents(t, rec, SLORecovered)
+}
+
+func TestJob_SLOMustCompleteWithin(t *testing.T) {
+	scheduler, rec := newSLOScheduler()
+	job := scheduler.Every(1).Hour().SLO(SLOSpec{MustCompleteWithin: time.Minute})
+	job.Do(func() {})
+
+	due := job.NextScheduledTime()
+	scheduler.checkSLOs(due.Add(30 * time.Second))
+	expectSLOEvents(t, rec)
+	scheduler.checkSLOs(due.Add(2 * time.Minute))
+	events := expectSLOEvents(t, rec, SLOViolated)
+	if e := events[0]; e.Clause != SLOMustCompleteWithin || e.Duration != time.Minute {
+		t.Errorf("got %+v, want a completion window violation a minute late", e)
+	}
+	scheduler.checkSLOs(due.Add(3 * time.Minute))
+	expectSLOEvents(t, rec)
+
+	// a successful run moves the window on to the next occurrence
+	runAllAndWait(t, scheduler)
+	expectSLOEvents(t, rec, SLORecovered)
+	if _, ok := NewJob(1).SLOStatus(); ok {
+		t.Error("a job without an SLO should report none")
+	}
+}
@@ -0,0 +1,34 @@
+package gocron
+
+import "time"
+
+// RunAllWithDelay - Run all jobs now, one every d, to spread the load
+// they make over time. The runs go through the scheduler like those of
+// Job.RunNow, under its concurrency limit, and by default, or with
+// RunKeepSchedule, leave the jobs' schedules as they are. It returns once
+// the last job has been dispatched.
+func (s *Scheduler) RunAllWithDelay(d time.Duration, m ...RunMode) {
+	s.stagger(func() time.Duration { return d }, runMode(m))
+}
+
+// RunAllWithJitteredDelay - Like RunAllWithDelay, waiting d plus a random
+// part of jitter between two jobs, drawn from the source SetRandSource set
+func (s *Scheduler) RunAllWithJitteredDelay(d, jitter time.Duration, m ...RunMode) {
+	s.stagger(func() time.Duration {
+		if jitter <= 0 {
+			return d
+		}
+		return d + time.Duration(s.randN(int64(jitter)))
+	}, runMode(m))
+}
+
+// Run the jobs in mode m, waiting delay() on the scheduler's clock
+// between two of them
+func (s *Scheduler) stagger(delay func() time.Duration, m RunMode) {
+	for i, job := range s.snapshot() {
+		if i > 0 {
+			s.sleep(delay())
+		}
+		s.runNowMode(job, m)
+	}
+}
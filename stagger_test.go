@@ -0,0 +1,111 @@
+package gocron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+// Three hourly jobs reporting when they start on the fake clock
+func staggered(t *testing.T) (*Scheduler, *clock.Fake, []*Job, chan time.Time) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	starts := make(chan time.Time, 3)
+	var jobs []*Job
+	for i := 0; i < 3; i++ {
+		job := scheduler.Every(1).Hour()
+		if err := job.Do(func() { starts <- fake.Now() }); err != nil {
+			t.Fatal(err)
+		}
+		jobs = append(jobs, job)
+	}
+	return scheduler, fake, jobs, starts
+}
+
+// Let run go, advancing fake by each of the delays it sleeps, and return
+// the start times of the jobs
+func spacing(fake *clock.Fake, starts chan time.Time, delays []time.Duration, run func()) []time.Duration {
+	done := make(chan struct{})
+	go func() {
+		run()
+		close(done)
+	}()
+	prev := <-starts
+	var gaps []time.Duration
+	for _, d := range delays {
+		fake.BlockUntil(1)
+		fake.Advance(d)
+		at := <-starts
+		gaps = append(gaps, at.Sub(prev))
+		prev = at
+	}
+	<-done
+	return gaps
+}
+
+func TestScheduler_RunAllWithDelay(t *testing.T) {
+	scheduler, fake, jobs, starts := staggered(t)
+	next := jobs[0].NextRun()
+	delays := []time.Duration{10 * time.Second, 10 * time.Second}
+	gaps := spacing(fake, starts, delays, func() { scheduler.RunAllWithDelay(10 * time.Second) })
+	for i, gap := range gaps {
+		if gap != delays[i] {
+			t.Errorf("gap %d between starts is %v, want %v", i, gap, delays[i])
+		}
+	}
+	if !jobs[0].NextRun().Equal(next) {
+		t.Error("RunAllWithDelay moved the schedule")
+	}
+}
+
+func TestScheduler_RunAllwithDelaySeconds(t *testing.T) {
+	scheduler, fake, jobs, starts := staggered(t)
+	next := jobs[0].NextRun()
+	delays := []time.Duration{2 * time.Second, 2 * time.Second}
+	gaps := spacing(fake, starts, delays, func() { scheduler.RunAllwithDelay(2) })
+	for i, gap := range gaps {
+		if gap != delays[i] {
+			t.Errorf("gap %d between starts is %v, want 2 seconds", i, gap)
+		}
+	}
+	if !jobs[0].NextRun().After(next) {
+		t.Error("RunAllwithDelay should still reschedule the jobs")
+	}
+}
+
+func TestScheduler_RunAllWithJitteredDelay(t *testing.T) {
+	scheduler, fake, _, starts := staggered(t)
+	scheduler.SetRandSource(rand.New(rand.NewSource(7)))
+	draws := rand.New(rand.NewSource(7))
+	var delays []time.Duration
+	for i := 0; i < 2; i++ {
+		delays = append(delays, 10*time.Second+time.Duration(draws.Int63n(int64(5*time.Second))))
+	}
+	gaps := spacing(fake, starts, delays, func() { scheduler.RunAllWithJitteredDelay(10*time.Second, 5*time.Second) })
+	for i, gap := range gaps {
+		if gap != delays[i] || gap < 10*time.Second || gap >= 15*time.Second {
+			t.Errorf("gap %d between starts is %v, want %v within 10s to 15s", i, gap, delays[i])
+		}
+	}
+}
+
+func TestScheduler_RunAllWithDelayLimit(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetMaxConcurrentJobs(1, LimitReschedule)
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	first := scheduler.Every(1).Hour()
+	first.Do(stuckJob, started, release)
+	second := scheduler.Every(1).Hour()
+	second.Do(task)
+
+	scheduler.RunAllWithDelay(0)
+	close(release)
+	<-scheduler.inflight.idle()
+	if first.RunCount() != 1 || second.RunCount() != 0 || second.SkippedRuns() != 1 {
+		t.Errorf("runs %d and %d, %d skipped, want the second job held back by the limit", first.RunCount(), second.RunCount(), second.SkippedRuns())
+	}
+}
@@ -0,0 +1,185 @@
+package gocron
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JobState - What Export saves of a job so that Load can carry its timing
+// over to a new process. Functions cannot be saved: Load matches states
+// to jobs registered again by their tags or, for jobs without tags, their
+// function name.
+type JobState struct {
+	Func string   `json:"func"`
+	Tags []string `json:"tags,omitempty"`
+
+	// Schedule describes the whole schedule, as Job.String does, and is
+	// what Load compares to tell whether the schedule changed
+	Schedule string `json:"schedule"`
+	Unit     string `json:"unit,omitempty"`
+	Interval uint64 `json:"interval,omitempty"`
+	At       string `json:"at,omitempty"`
+
+	LastRun  time.Time `json:"last_run"`
+	NextRun  time.Time `json:"next_run"`
+	RunCount int       `json:"run_count"`
+	// retries so far, see Job.Retries
+	Retries int `json:"retries,omitempty"`
+	// the job's configuration version, see Job.Version
+	Version uint64 `json:"version"`
+
+	// Paused is set for a job paused with Job.Pause, and Remaining is the
+	// time that was left until its next run when it was frozen
+	Paused    bool          `json:"paused,omitempty"`
+	PauseMode PauseMode     `json:"pause_mode,omitempty"`
+	Remaining time.Duration `json:"remaining,omitempty"`
+}
+
+// Export - The state of every scheduled job, in the scheduler's order
+func (s *Scheduler) Export() []JobState {
+	var states []JobState
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		if job.jobFunc != "" {
+			states = append(states, job.state())
+		}
+		job.mu.Unlock()
+	}
+	return states
+}
+
+// MarshalJSON - The scheduler's Export as JSON, so a scheduler can be
+// saved with json.Marshal
+func (s *Scheduler) MarshalJSON() ([]byte, error) {
+	states := s.Export()
+	if states == nil {
+		states = []JobState{}
+	}
+	return json.Marshal(states)
+}
+
+// Load - Restore the timing of jobs from their states saved by Export,
+// once the jobs are registered again with Do, returning how many were
+// matched. A job matches a state with the same tags or, when both have
+// none, the same function; jobs that match several states take them in
+// order. A job with its schedule unchanged gets its last and next run
+// and its run and retry counts back, so a run that was about to fire
+// still does and RunNumber goes on where it left off. A job paused with
+// Job.Pause is paused again, frozen with the time it had left. A job whose
+// schedule changed keeps the next run Do computed, counted from the saved
+// last run for interval jobs, and gets its counts back. States without a
+// job are ignored.
+func (s *Scheduler) Load(states []JobState) int {
+	jobs := s.snapshot()
+	used := make([]bool, len(jobs))
+	matched := 0
+	for _, st := range states {
+		for i, job := range jobs {
+			if used[i] || !job.matches(st) {
+				continue
+			}
+			used[i] = true
+			matched++
+			job.mu.Lock()
+			job.restore(st)
+			job.mu.Unlock()
+			break
+		}
+	}
+	if matched > 0 {
+		s.wakeup()
+	}
+	return matched
+}
+
+// The job's state, requires j.mu held
+func (j *Job) state() JobState {
+	st := JobState{
+		Func:     j.jobFunc,
+		Tags:     append([]string(nil), j.tags...),
+		Schedule: j.describe(),
+		Unit:     j.unit,
+		Interval: j.interval,
+		At:       j.atTime,
+		LastRun:  j.lastRun,
+		NextRun:  j.nextRun,
+		RunCount: j.runCount,
+		Retries:  j.retries,
+		Version:  j.version,
+	}
+	if j.paused {
+		st.Paused, st.PauseMode, st.Remaining = true, j.pauseMode, j.remaining
+	}
+	if j.cron != nil {
+		st.Unit, st.Interval = "", 0
+	}
+	return st
+}
+
+// Whether st was saved from this job, by tags or else function name
+func (j *Job) matches(st JobState) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.jobFunc == "" {
+		return false
+	}
+	if len(st.Tags) > 0 || len(j.tags) > 0 {
+		return sameTags(st.Tags, j.tags)
+	}
+	return st.Func == j.jobFunc
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return strings.Join(a, "\x00") == strings.Join(b, "\x00")
+}
+
+// Carry st over to the job, requires j.mu held
+func (j *Job) restore(st JobState) {
+	j.runCount = st.RunCount
+	j.retries = st.Retries
+	// versions only go up, the job may have been changed before Load
+	if st.Version > j.version {
+		j.version = st.Version
+	}
+	j.restorePause(st)
+	if j.limit > 0 && j.runCount >= j.limit {
+		j.removed = true
+	}
+	if st.RunCount > 0 && j.startedAt.IsZero() {
+		j.startedAt = st.LastRun
+	}
+	if st.Schedule == j.describe() {
+		j.lastRun = st.LastRun
+		j.nextRun = st.NextRun
+		return
+	}
+	if j.intervalOnly() && st.RunCount > 0 {
+		j.lastRun = st.LastRun
+		j.scheduleNextRun()
+	}
+}
+
+// Pause the job again when it was paused as st was saved, freezing its
+// schedule only when the job can still be frozen. Requires j.mu held.
+func (j *Job) restorePause(st JobState) {
+	if !st.Paused {
+		return
+	}
+	if !j.paused {
+		j.paused = true
+		j.version++
+	}
+	j.pauseMode, j.remaining = PauseRecomputeOnResume, 0
+	if st.PauseMode == PauseFreezeSchedule && j.freezable() {
+		j.pauseMode, j.remaining = PauseFreezeSchedule, st.Remaining
+	}
+}
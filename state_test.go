@@ -0,0 +1,169 @@
+package gocron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func report() {}
+
+func TestScheduler_ExportLoad(t *testing.T) {
+	old := NewScheduler()
+	daily := old.Every(1).Day().At("10:30").Tag("report")
+	hourly := old.Every(1).Hour()
+	daily.Do(func() {})
+	hourly.Do(task)
+	old.Every(1).Minute() // no Do, not exported
+
+	// the hourly job is about to fire after three runs
+	soon := time.Now().Add(time.Minute).Truncate(time.Second)
+	hourly.mu.Lock()
+	hourly.lastRun, hourly.nextRun, hourly.runCount = soon.Add(-time.Hour), soon, 3
+	hourly.mu.Unlock()
+
+	b, err := json.Marshal(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []JobState
+	if err := json.Unmarshal(b, &states); err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 2 || states[0].Tags[0] != "report" || states[1].Schedule != "every hour" {
+		t.Fatalf("exported %+v", states)
+	}
+
+	restarted := NewScheduler()
+	// a different function, matched by its tag
+	newDaily := restarted.Every(1).Day().At("10:30").Tag("report")
+	newDaily.Do(report)
+	newHourly := restarted.Every(1).Hour()
+	newHourly.Do(task)
+	untouched := restarted.Every(1).Hour()
+	untouched.Do(report)
+	before := untouched.NextScheduledTime()
+
+	if n := restarted.Load(append(states, JobState{Func: "gone"})); n != 2 {
+		t.Errorf("Load() matched %d jobs, want 2", n)
+	}
+	if !newHourly.NextScheduledTime().Equal(soon) || newHourly.RunCount() != 3 {
+		t.Errorf("hourly job next at %v after %d runs, want %v after 3", newHourly.NextScheduledTime(), newHourly.RunCount(), soon)
+	}
+	if !newHourly.LastRun().Equal(soon.Add(-time.Hour)) {
+		t.Errorf("LastRun() = %v, want the saved run", newHourly.LastRun())
+	}
+	if !newDaily.NextScheduledTime().Equal(daily.NextScheduledTime()) {
+		t.Errorf("daily job next at %v, want %v", newDaily.NextScheduledTime(), daily.NextScheduledTime())
+	}
+	if !untouched.NextScheduledTime().Equal(before) {
+		t.Error("a job without a state was changed")
+	}
+}
+
+func TestScheduler_LoadChangedSchedule(t *testing.T) {
+	last := time.Now().Add(-3 * time.Minute)
+	states := []JobState{{
+		Func:     getFunctionName(task),
+		Schedule: "every 10 minutes",
+		LastRun:  last,
+		NextRun:  last.Add(10 * time.Minute),
+		RunCount: 7,
+	}, {
+		Tags:     []string{"nightly"},
+		Schedule: "every day at 02:00",
+		NextRun:  time.Now().Add(time.Hour),
+		RunCount: 2,
+	}}
+
+	scheduler := NewScheduler()
+	minutely := scheduler.Every(5).Minutes()
+	minutely.Do(task)
+	nightly := scheduler.Every(1).Day().At("03:00").Tag("nightly")
+	nightly.Do(report)
+	fresh := nightly.NextScheduledTime()
+
+	if n := scheduler.Load(states); n != 2 {
+		t.Fatalf("Load() matched %d jobs, want 2", n)
+	}
+	if got, want := minutely.NextScheduledTime(), last.Add(5*time.Minute); !got.Equal(want) || minutely.RunCount() != 7 {
+		t.Errorf("next run %v, want the new interval from the saved run at %v", got, want)
+	}
+	if !nightly.NextScheduledTime().Equal(fresh) || nightly.RunCount() != 2 {
+		t.Errorf("job with a new At time next at %v, want %v", nightly.NextScheduledTime(), fresh)
+	}
+}
+
+func TestScheduler_LoadContinuesRunNumbers(t *testing.T) {
+	var calls []string
+	export := func(ctx context.Context) error {
+		n, _ := RunNumber(ctx)
+		attempt, _ := RunAttempt(ctx)
+		calls = append(calls, fmt.Sprintf("%d.%d", n, attempt))
+		if attempt < 2 {
+			return errors.New("flaky")
+		}
+		return nil
+	}
+	old := NewScheduler()
+	old.Every(1).Hour().Tag("export").Retry(1, 0).Do(export)
+	for i := 0; i < 2; i++ {
+		old.RunAll()
+		old.Wait(time.Second)
+	}
+
+	restarted := NewScheduler()
+	job := restarted.Every(1).Hour().Tag("export").Retry(1, 0)
+	job.Do(export)
+	if n := restarted.Load(old.Export()); n != 1 {
+		t.Fatalf("Load() matched %d jobs, want 1", n)
+	}
+	restarted.RunAll()
+	restarted.Wait(time.Second)
+
+	if got := strings.Join(calls, " "); got != "1.1 1.2 2.1 2.2 3.1 3.2" {
+		t.Errorf("calls %s, want the numbers to go on after the restart", got)
+	}
+	if job.Retries() != 3 {
+		t.Errorf("Retries() = %d after the restart, want 3", job.Retries())
+	}
+}
+
+func TestScheduler_LoadPaused(t *testing.T) {
+	register := func(s *Scheduler) (frozen, recompute, running *Job) {
+		frozen = s.Every(1).Hour().Tag("frozen")
+		recompute = s.Every(1).Day().At("06:00").Tag("recompute")
+		running = s.Every(1).Minute().Tag("running")
+		for _, job := range []*Job{frozen, recompute, running} {
+			job.Do(task)
+		}
+		return frozen, recompute, running
+	}
+	old, fake := pauseScheduler()
+	frozen, recompute, _ := register(old)
+	fake.Advance(20 * time.Minute)
+	frozen.Pause(PauseFreezeSchedule)
+	recompute.Pause()
+
+	restarted, fake := pauseScheduler()
+	fake.Advance(5 * time.Hour)
+	frozen, recompute, running := register(restarted)
+	restarted.Load(old.Export())
+
+	if !frozen.IsPaused() || !recompute.IsPaused() || running.IsPaused() {
+		t.Fatal("Load() should pause exactly the jobs that were paused")
+	}
+	restarted.RunPendingAndWait()
+	if n := frozen.RunCount() + recompute.RunCount(); n != 0 {
+		t.Errorf("%d runs of restored paused jobs", n)
+	}
+	// frozen 40 minutes before its run, it owes them after the resume
+	frozen.Resume()
+	if want := fake.Now().Add(40 * time.Minute); !frozen.NextRun().Equal(want) {
+		t.Errorf("frozen job next at %v after Resume, want %v", frozen.NextRun(), want)
+	}
+}
@@ -0,0 +1,102 @@
+package gocron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LastRun - When the job's latest run started, the zero time if it has
+// not run yet
+func (j *Job) LastRun() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.startedAt
+}
+
+// NextRun - When the job is to run next, the zero time if it is not
+// scheduled, because Do has not been called or the job removed itself
+func (j *Job) NextRun() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.untilNextRun(j.now()); !ok {
+		return time.Time{}
+	}
+	return j.nextRun
+}
+
+// IsRunning - Whether a run of the job is going
+func (j *Job) IsRunning() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.active > 0
+}
+
+// String - The job's name, schedule and next run, e.g.
+// "[billing-sync] every day at 02:00, next 2024-05-01T02:00:00Z"
+func (j *Job) String() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := j.describe()
+	if name := j.displayName(); name != "" {
+		s = "[" + name + "] " + s
+	}
+	if _, ok := j.untilNextRun(j.now()); ok {
+		s += ", next " + j.nextRun.Format(time.RFC3339)
+	}
+	return s
+}
+
+// The schedule part of String, requires j.mu held
+func (j *Job) describe() string {
+	var s string
+	switch j.unit {
+	case unitCron:
+		return "cron " + j.cron.expr
+	case unitDuration:
+		s = "every " + time.Duration(j.interval).String()
+	case UnitWeeks:
+		day := strings.ToLower(j.startDay.String())
+		if j.weekdays.len() > 1 {
+			s = "every " + j.weekdays.String()
+		} else if j.interval == 1 {
+			s = "every " + day
+		} else {
+			s = "every " + strconv.FormatUint(j.interval, 10) + " weeks on " + day
+		}
+	case UnitMonths:
+		if j.interval == 1 {
+			s = "every month"
+		} else {
+			s = "every " + strconv.FormatUint(j.interval, 10) + " months"
+		}
+		if j.dayOfMonth > 0 {
+			s += " on day " + strconv.Itoa(j.dayOfMonth)
+		}
+		if j.monthWeek > 0 {
+			s += " on the first " + strings.ToLower(j.monthWeekday.String())
+		} else if j.monthWeek < 0 {
+			s += " on the last " + strings.ToLower(j.monthWeekday.String())
+		}
+	case "":
+		s = "every " + strconv.FormatUint(j.interval, 10)
+	default:
+		unit := j.unit
+		if j.randomUpper > 0 {
+			s = "every " + strconv.FormatUint(j.interval, 10) + " to " + strconv.FormatUint(j.randomUpper, 10) + " " + unit
+		} else if j.interval == 1 {
+			s = "every " + strings.TrimSuffix(unit, "s")
+		} else {
+			s = "every " + strconv.FormatUint(j.interval, 10) + " " + unit
+		}
+	}
+	if j.atTime != "" {
+		s += " at " + j.atTime
+	}
+	return s
+}
+
+// Drop the import path from a function name, "a/b/pkg.fn" is "pkg.fn"
+func shortFuncName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,106 @@
+package gocron
+
+import (
+	"testing"
+	"time"
+)
+
+func statusJob() {}
+
+func TestJob_String(t *testing.T) {
+	scheduler := NewScheduler()
+	cases := []struct {
+		job  *Job
+		want string
+	}{
+		{scheduler.Every(5).Minutes().At("30"), "every 5 minutes at 30"},
+		{scheduler.Every(2).Hours().At("10:30"), "every 2 hours at 10:30"},
+		{scheduler.Every(1).Hour(), "every hour"},
+		{scheduler.Every(1).Day().At("08:00"), "every day at 08:00"},
+		{scheduler.Every(1).Monday(), "every monday"},
+		{scheduler.Every(2).Weeks(), "every 2 weeks on sunday"},
+		{scheduler.EveryDuration(1500 * time.Millisecond), "every 1.5s"},
+		{scheduler.Cron("0 9 * * 1-5"), "cron 0 9 * * 1-5"},
+	}
+	for _, c := range cases {
+		if got := c.job.String(); got != c.want {
+			t.Errorf("String() = %q, want %q", got, c.want)
+		}
+		c.job.Do(statusJob)
+		next := c.job.NextRun().Format(time.RFC3339)
+		if got, want := c.job.String(), "[gocron.statusJob] "+c.want+", next "+next; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+// The schedule part of j's String, without the next run
+func schedule(j *Job) string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.describe()
+}
+
+func TestJob_Status(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	job := scheduler.Every(1).Hour()
+	if !job.NextRun().IsZero() {
+		t.Error("NextRun() of a job without Do should be zero")
+	}
+	job.Do(stuckJob, started, release)
+	if !job.LastRun().IsZero() || job.IsRunning() {
+		t.Error("a job that has not run reports a run")
+	}
+	if next := job.NextRun(); next.Before(time.Now()) {
+		t.Errorf("NextRun() = %v, want within the hour", next)
+	}
+
+	begin := time.Now()
+	scheduler.RunAll()
+	<-started
+	if !job.IsRunning() {
+		t.Error("IsRunning() = false during a run")
+	}
+	if last := job.LastRun(); last.Before(begin) {
+		t.Errorf("LastRun() = %v, want the start of the run", last)
+	}
+	close(release)
+	if err := scheduler.Wait(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if job.IsRunning() {
+		t.Error("IsRunning() = true after the run returned")
+	}
+	if job.RunCount() != 1 {
+		t.Errorf("RunCount() = %d, want 1", job.RunCount())
+	}
+}
+
+func TestJob_TimeUntilNextRun(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Hour()
+	if _, ok := job.TimeUntilNextRun(); ok {
+		t.Error("a job without Do should not be scheduled")
+	}
+
+	job.Do(task)
+	d, ok := job.TimeUntilNextRun()
+	if !ok || d <= 59*time.Minute || d > time.Hour {
+		t.Errorf("TimeUntilNextRun() = %v, %v; want about an hour", d, ok)
+	}
+
+	// overdue, e.g. paused or blocked behind its lane
+	job.mu.Lock()
+	job.nextRun = time.Now().Add(-time.Minute)
+	job.mu.Unlock()
+	if d, ok := job.TimeUntilNextRun(); !ok || d > -time.Minute {
+		t.Errorf("TimeUntilNextRun() = %v, %v; want a minute overdue", d, ok)
+	}
+
+	job.RemoveSelf()
+	if _, ok := job.TimeUntilNextRun(); ok {
+		t.Error("a job that removed itself should not be scheduled")
+	}
+}
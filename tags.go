@@ -0,0 +1,80 @@
+package gocron
+
+import "errors"
+
+// Tag - Add tags to the job, e.g. s.Every(1).Hour().Tag("tenant-42").Do(task),
+// so it can be found or removed with FindJobsByTag and RemoveByTag. With
+// TagsUnique, a tag another job of the scheduler has is an error instead.
+func (j *Job) Tag(tags ...string) *Job {
+	if s := j.scheduler; s != nil {
+		s.mu.Lock()
+		if err := s.tagsTaken(j, tags); err != nil {
+			s.mu.Unlock()
+			j.warn(err)
+			j.setErr(err)
+			return j
+		}
+		// no other job can take the tags meanwhile
+		defer s.mu.Unlock()
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, tag := range tags {
+		if !j.hasTag(tag) {
+			j.tags = append(j.tags, tag)
+		}
+	}
+	j.version++
+	return j
+}
+
+// Tags - A copy of the job's tags
+func (j *Job) Tags() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]string(nil), j.tags...)
+}
+
+// hasTag reports whether the job has tag, j.mu must be held
+func (j *Job) hasTag(tag string) bool {
+	for _, t := range j.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *Job) tagged(tag string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.hasTag(tag)
+}
+
+// Jobs - A copy of the scheduled jobs
+func (s *Scheduler) Jobs() []*Job {
+	return s.snapshot()
+}
+
+// FindJobsByTag - The jobs tagged with tag
+func (s *Scheduler) FindJobsByTag(tag string) []*Job {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var found []*Job
+	for _, job := range s.jobs {
+		if job.tagged(tag) {
+			found = append(found, job)
+		}
+	}
+	return found
+}
+
+// RemoveByTag - Remove every job tagged with tag, returning an error if there is none
+func (s *Scheduler) RemoveByTag(tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.removeMatching(func(job *Job) bool { return job.tagged(tag) }, false) == 0 {
+		return errors.New("no job tagged " + tag)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package gocron
+
+import "testing"
+
+func TestScheduler_Tags(t *testing.T) {
+	scheduler := NewScheduler()
+	syncTenant := func(tenant string) {}
+	acme := scheduler.Every(1).Hour().Tag("sync", "acme")
+	acme.Do(syncTenant, "acme")
+	globex := scheduler.Every(1).Hour().Tag("sync", "globex")
+	globex.Do(syncTenant, "globex")
+	scheduler.Every(1).Day().Tag("report").Do(task)
+
+	if tags := acme.Tags(); len(tags) != 2 || tags[0] != "sync" || tags[1] != "acme" {
+		t.Errorf("Tags() = %v", tags)
+	}
+	if found := scheduler.FindJobsByTag("sync"); len(found) != 2 {
+		t.Errorf("FindJobsByTag(sync) found %d jobs, want 2", len(found))
+	}
+
+	if err := scheduler.RemoveByTag("globex"); err != nil {
+		t.Fatal(err)
+	}
+	jobs := scheduler.Jobs()
+	if len(jobs) != 2 {
+		t.Fatalf("have %d jobs after RemoveByTag, want 2", len(jobs))
+	}
+	for _, job := range jobs {
+		if job == globex {
+			t.Error("RemoveByTag left the tagged job in place")
+		}
+	}
+
+	if err := scheduler.RemoveByTag("globex"); err == nil {
+		t.Error("RemoveByTag should fail when no job matches")
+	}
+	if err := scheduler.RemoveByTag("sync"); err != nil || len(scheduler.Jobs()) != 1 {
+		t.Errorf("RemoveByTag(sync) should remove every tagged job; err %v", err)
+	}
+}
+
+func TestJob_TagDeduplicates(t *testing.T) {
+	job := NewJob(1).Tag("a", "b").Tag("a")
+	if tags := job.Tags(); len(tags) != 2 {
+		t.Errorf("Tags() = %v, want [a b]", tags)
+	}
+}
@@ -0,0 +1,48 @@
+package gocron
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTimeout - The error OnError and the error handler get for a run that
+// took longer than its Timeout
+var ErrTimeout = errors.New("run timed out")
+
+// Timeout - Give up on runs of the job that take longer than d. A function
+// taking a context.Context first gets one that is cancelled after d.
+// Either way the run counts as failed then: OnError and the error handler
+// get ErrTimeout, a singleton job may start its next run and the run frees
+// its place under the concurrency limit. The call itself cannot be stopped
+// and is left to finish on its own; what it returns is logged, not counted
+// again. Its ordering lane stays taken until it does, so that the calls of
+// a key never overlap. d covers the retries of a run too.
+func (j *Job) Timeout(d time.Duration) *Job {
+	if !j.building("Timeout") {
+		return j
+	}
+	if d <= 0 {
+		j.setErr(errors.New("Timeout() requires a positive duration, got " + d.String()))
+		return j
+	}
+	j.mu.Lock()
+	j.timeout = d
+	j.mu.Unlock()
+	return j
+}
+
+// Log what a call returned after its run timed out
+func (j *Job) returnedLate(r runResult) {
+	s := j.scheduler
+	if s == nil {
+		return
+	}
+	name := j.funcName()
+	if r.recovered != nil {
+		s.logf("job %s panicked after timing out: %v", name, r.recovered)
+	} else if err := resultError(r.out); err != nil {
+		s.logf("job %s returned after timing out: %v", name, err)
+	} else {
+		s.logf("job %s returned after timing out", name)
+	}
+}
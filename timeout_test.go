@@ -0,0 +1,125 @@
+package gocron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jasonlvhit/gocron/clock"
+)
+
+func TestJob_Timeout(t *testing.T) {
+	scheduler := NewScheduler()
+	logger := &recordingLogger{}
+	scheduler.SetLogger(logger)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	scheduler.SetClock(fake)
+	var mu sync.Mutex
+	var failures []error
+	started := make(chan time.Time, 10)
+	release := make(chan struct{})
+	job := scheduler.EveryDuration(2 * time.Second).SingletonMode().Timeout(time.Second).OnError(func(err error) {
+		mu.Lock()
+		failures = append(failures, err)
+		mu.Unlock()
+	})
+	// hangs far past its timeout, like a poll that never returns
+	job.Do(func() {
+		started <- fake.Now()
+		<-release
+	})
+
+	fake.Advance(2*time.Second + time.Millisecond)
+	scheduler.RunPending()
+	<-started
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	<-scheduler.inflight.idle()
+	mu.Lock()
+	if len(failures) != 1 || failures[0] != ErrTimeout {
+		t.Errorf("OnError got %v, want ErrTimeout", failures)
+	}
+	mu.Unlock()
+	if job.IsRunning() {
+		t.Error("a run that timed out still counts as running")
+	}
+
+	// the singleton run is released, the next one fires on time
+	fake.Advance(time.Second)
+	scheduler.RunPending()
+	if at := <-started; !at.Equal(start.Add(4*time.Second + time.Millisecond)) {
+		t.Errorf("next run at %v, want on schedule", at)
+	}
+	if job.RunCount() != 2 || job.SkippedRuns() != 0 {
+		t.Errorf("%d runs and %d skipped, want 2 runs", job.RunCount(), job.SkippedRuns())
+	}
+
+	// time the second run out too, then let both calls return
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	<-scheduler.inflight.idle()
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		logger.mu.Lock()
+		lines := strings.Join(logger.lines, "\n")
+		logger.mu.Unlock()
+		if strings.Count(lines, "returned after timing out") == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("logged %q, want both late returns", lines)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if job.RunCount() != 2 {
+		t.Errorf("RunCount() = %d after the calls returned, want them not counted again", job.RunCount())
+	}
+}
+
+func TestJob_TimeoutContext(t *testing.T) {
+	scheduler := NewScheduler()
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	scheduler.SetClock(fake)
+	cancelled := make(chan error, 1)
+	var got error
+	scheduler.SetErrorHandler(func(job *Job, err error) { got = err })
+	job := scheduler.Every(1).Hour().Timeout(time.Minute)
+	job.Do(func(ctx context.Context) {
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+	})
+
+	scheduler.RunAll()
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	if err := <-cancelled; err != context.Canceled {
+		t.Errorf("ctx.Err() = %v, want the run's context cancelled", err)
+	}
+	<-scheduler.inflight.idle()
+	if got != ErrTimeout {
+		t.Errorf("error handler got %v, want ErrTimeout", got)
+	}
+}
+
+func TestJob_TimeoutNotReached(t *testing.T) {
+	scheduler := NewScheduler()
+	var got error
+	scheduler.SetErrorHandler(func(job *Job, err error) { got = err })
+	failure := errors.New("down")
+	job := scheduler.Every(1).Hour().Timeout(time.Hour)
+	job.Do(func() error { return failure })
+
+	scheduler.RunAll()
+	<-scheduler.inflight.idle()
+	if got != failure {
+		t.Errorf("error handler got %v, want the run's own error", got)
+	}
+	if err := scheduler.Every(1).Hour().Timeout(0).Do(task); err == nil {
+		t.Error("Timeout(0) should fail")
+	}
+}
@@ -0,0 +1,106 @@
+package gocron
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jasonlvhit/gocron/internal/compat"
+)
+
+// TimeZoneChange - A job whose next run moved when RefreshTimeZones
+// reloaded its time zone
+type TimeZoneChange struct {
+	Job *Job
+	// Zone is the name of the reloaded location, e.g. "America/Sao_Paulo"
+	Zone       string
+	OldNextRun time.Time
+	NewNextRun time.Time
+}
+
+// SetTimeZoneHandler - Call fn for every job RefreshTimeZones reschedules
+func (s *Scheduler) SetTimeZoneHandler(fn func(TimeZoneChange)) {
+	s.mu.Lock()
+	s.tzHandler = fn
+	s.mu.Unlock()
+}
+
+// RefreshTimeZones - Reload the named time zones of jobs pinned to a time
+// of day or the calendar, i.e. using At, Cron or Months, and recompute
+// their next runs under the reloaded rules, e.g. after the tz database
+// was updated because a country dropped DST. It returns how many jobs
+// got a different next run, each of which is passed to the handler set
+// with SetTimeZoneHandler. Jobs in time.Local, UTC or a fixed-offset zone
+// are left alone. err joins the errors of the zones that fail to load,
+// the other jobs are refreshed anyway.
+func (s *Scheduler) RefreshTimeZones() (changed int, err error) {
+	s.mu.RLock()
+	load, handler := s.loadLocation, s.tzHandler
+	s.mu.RUnlock()
+	if load == nil {
+		load = time.LoadLocation
+	}
+
+	var errs []error
+	loaded := make(map[string]*time.Location)
+	for _, job := range s.snapshot() {
+		job.mu.Lock()
+		l := job.location()
+		name := l.String()
+		if !job.zoneDependent() || l == time.Local || name == "UTC" || name == "Local" {
+			job.mu.Unlock()
+			continue
+		}
+		job.mu.Unlock()
+
+		fresh, ok := loaded[name]
+		if !ok {
+			var loadErr error
+			fresh, loadErr = load(name)
+			// fixed-offset zones have made up names that do not load
+			if loadErr != nil && strings.Contains(name, "/") {
+				errs = append(errs, loadErr)
+			}
+			loaded[name] = fresh
+		}
+		if fresh == nil {
+			continue
+		}
+
+		job.mu.Lock()
+		old := job.nextRun
+		job.relocate(fresh)
+		next := job.nextRun
+		job.mu.Unlock()
+		if !next.Equal(old) {
+			changed++
+			if handler != nil {
+				handler(TimeZoneChange{Job: job, Zone: name, OldNextRun: old, NewNextRun: next})
+			}
+		}
+	}
+	return changed, compat.Join(errs...)
+}
+
+// Move the job to fresh, its zone reloaded. The last and next runs keep
+// their wall-clock times, so they move only when the zone's rules did.
+// Requires j.mu held.
+func (j *Job) relocate(fresh *time.Location) {
+	old := j.location()
+	j.loc = fresh
+	if j.lastRun != time.Unix(0, 0) {
+		j.lastRun = sameWallClock(j.lastRun, old, fresh)
+	}
+	j.nextRun = sameWallClock(j.nextRun, old, fresh)
+	j.scheduler.wakeup()
+}
+
+// The time in to reading as t reads in from
+func sameWallClock(t time.Time, from, to *time.Location) time.Time {
+	w := t.In(from)
+	return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), to)
+}
+
+// Whether the job's runs follow the wall clock of its location, requires j.mu held
+func (j *Job) zoneDependent() bool {
+	return j.jobFunc != "" && (len(j.ats) > 0 || j.cron != nil || j.unit == UnitMonths)
+}
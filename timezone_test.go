@@ -0,0 +1,160 @@
+package gocron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduler_RefreshTimeZones(t *testing.T) {
+	before := time.FixedZone("America/Testville", -3*3600)
+	after := time.FixedZone("America/Testville", -2*3600)
+
+	scheduler := NewScheduler()
+	var changes []TimeZoneChange
+	scheduler.SetTimeZoneHandler(func(c TimeZoneChange) { changes = append(changes, c) })
+	scheduler.loadLocation = func(name string) (*time.Location, error) {
+		if name == "America/Testville" {
+			return after, nil
+		}
+		return nil, errors.New("unknown time zone " + name)
+	}
+
+	moved := scheduler.Every(1).Day().At("10:00").Loc(before)
+	moved.Do(func() {})
+	inUTC := scheduler.Every(1).Day().At("10:00").Loc(time.UTC)
+	inUTC.Do(func() {})
+	fixed := scheduler.Every(1).Day().At("10:00").Loc(time.FixedZone("UTC-3", -3*3600))
+	fixed.Do(func() {})
+	interval := scheduler.Every(1).Hour().Loc(before)
+	interval.Do(func() {})
+	unchanged := map[*Job]time.Time{}
+	for _, job := range []*Job{inUTC, fixed, interval} {
+		unchanged[job] = job.NextScheduledTime()
+	}
+	old := moved.NextScheduledTime()
+
+	changed, err := scheduler.RefreshTimeZones()
+	if err != nil || changed != 1 {
+		t.Fatalf("RefreshTimeZones() = %d, %v, want 1 changed job", changed, err)
+	}
+	next := moved.NextScheduledTime()
+	if got := next.In(after); got.Hour() != 10 || got.Minute() != 0 {
+		t.Errorf("next run %v, want 10:00 under the new rules", got)
+	}
+	if d := old.Sub(next); d != time.Hour && d != -23*time.Hour {
+		t.Errorf("next run moved by %v, want an hour earlier", d)
+	}
+	if len(changes) != 1 || changes[0].Job != moved || changes[0].Zone != "America/Testville" ||
+		!changes[0].OldNextRun.Equal(old) || !changes[0].NewNextRun.Equal(next) {
+		t.Errorf("got changes %+v", changes)
+	}
+	for job, want := range unchanged {
+		if !job.NextScheduledTime().Equal(want) {
+			t.Errorf("%s was rescheduled", job)
+		}
+	}
+
+	// a second refresh finds nothing new
+	if changed, _ := scheduler.RefreshTimeZones(); changed != 0 {
+		t.Errorf("second RefreshTimeZones() changed %d jobs", changed)
+	}
+}
+
+func TestScheduler_RefreshTimeZonesError(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.loadLocation = func(name string) (*time.Location, error) {
+		return nil, errors.New("no tzdata")
+	}
+	scheduler.Every(1).Day().At("10:00").Loc(time.FixedZone("Europe/Gone", 3600)).Do(func() {})
+	scheduler.Every(1).Day().At("10:00").Loc(time.FixedZone("Asia/Gone", 3600)).Do(func() {})
+	if _, err := scheduler.RefreshTimeZones(); err == nil || err.Error() != "no tzdata\nno tzdata" {
+		t.Errorf("RefreshTimeZones() = %v, want both zones that fail to load reported", err)
+	}
+}
+
+func TestScheduler_RefreshTimeZonesUnchanged(t *testing.T) {
+	zone := time.FixedZone("America/Testville", -3*3600)
+	scheduler := NewScheduler()
+	scheduler.loadLocation = func(name string) (*time.Location, error) {
+		return time.FixedZone(name, -3*3600), nil
+	}
+
+	biweekly := scheduler.Every(2).Monday().At("10:00").Loc(zone)
+	biweekly.Do(task)
+	daily := scheduler.Every(1).Day().At("10:00").Loc(zone)
+	daily.Do(task)
+	// biweekly ran on its first Monday, daily is due today but late
+	biweekly.mu.Lock()
+	first := biweekly.nextRun
+	biweekly.scheduleAfterRun(first, first)
+	biweekly.mu.Unlock()
+	daily.mu.Lock()
+	daily.nextRun = daily.nextRun.Add(-24 * time.Hour)
+	daily.mu.Unlock()
+	want := map[*Job]time.Time{biweekly: biweekly.NextScheduledTime(), daily: daily.NextScheduledTime()}
+	if d := want[biweekly].Sub(first); d != 14*24*time.Hour {
+		t.Fatalf("biweekly job next in %v after its run, want two weeks", d)
+	}
+
+	if changed, err := scheduler.RefreshTimeZones(); changed != 0 || err != nil {
+		t.Errorf("RefreshTimeZones() = %d, %v, want nothing changed", changed, err)
+	}
+	for job, next := range want {
+		if got := job.NextScheduledTime(); !got.Equal(next) {
+			t.Errorf("%s moved from %v to %v", job, next, got)
+		}
+	}
+}
+
+func TestJob_Loc(t *testing.T) {
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	job := NewScheduler().Every(1).Day().At("10:30").Loc(zone)
+	job.Do(task)
+
+	next := job.NextScheduledTime().In(zone)
+	if next.Hour() != 10 || next.Minute() != 30 {
+		t.Errorf("next run %s should be at 10:30 in %s", next, zone)
+	}
+	if d := next.Sub(time.Now()); d <= 0 || d > 24*time.Hour {
+		t.Errorf("next run %s should be within the next day", next)
+	}
+
+	// moving the job after Do recomputes the next run in the new zone
+	other := time.FixedZone("UTC-3", -3*60*60)
+	job.Loc(other)
+	next = job.NextScheduledTime().In(other)
+	if next.Hour() != 10 || next.Minute() != 30 {
+		t.Errorf("next run %s should be at 10:30 in %s", next, other)
+	}
+}
+
+func TestScheduler_ChangeLoc(t *testing.T) {
+	zone := time.FixedZone("UTC+9", 9*60*60)
+	scheduler := NewScheduler()
+	scheduler.ChangeLoc(zone)
+	job := scheduler.Every(1).Monday().At("08:00")
+	job.Do(task)
+
+	next := job.NextScheduledTime().In(zone)
+	if next.Weekday() != time.Monday || next.Hour() != 8 {
+		t.Errorf("next run %s should be Monday 08:00 in %s", next, zone)
+	}
+}
+
+func TestJob_LocDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	job := NewScheduler().Every(1).Day().At("10:30").Loc(newYork)
+	job.Do(task)
+
+	// daylight saving time ends on 2026-11-01
+	job.lastRun = time.Date(2026, time.October, 31, 10, 30, 0, 0, newYork)
+	job.scheduleNextRun()
+	want := time.Date(2026, time.November, 1, 10, 30, 0, 0, newYork)
+	if !job.nextRun.Equal(want) {
+		t.Errorf("next run %s, want %s", job.nextRun, want)
+	}
+}
@@ -0,0 +1,54 @@
+package gocron
+
+import "errors"
+
+// SetInterval - Change the job to run every n of its units, keeping its
+// unit, At times and run history. The next run is recomputed from the
+// last one right away, so it is safe to call while the scheduler runs.
+func (j *Job) SetInterval(n uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.reschedule(n, j.unit)
+}
+
+// SetUnit - Change the unit the job's interval counts, e.g. from
+// UnitHours to UnitMinutes, see SetInterval
+func (j *Job) SetUnit(unit string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.reschedule(j.interval, unit)
+}
+
+// SetAt - Replace the job's At times with t, in the forms At takes, or
+// drop them when t is empty, see SetInterval. It returns
+// ErrVersionConflict if the job is rescheduled meanwhile.
+func (j *Job) SetAt(t string) error {
+	j.mu.Lock()
+	if j.cron != nil {
+		j.mu.Unlock()
+		return errors.New("SetAt() cannot be used with a cron schedule")
+	}
+	// parse on a scratch job, At may log and the logger must not be
+	// reached with j.mu held
+	scratch := &Job{unit: j.unit, startDay: j.startDay, loc: j.loc, clock: j.clock, scheduler: j.scheduler}
+	version := j.version
+	j.mu.Unlock()
+	if t != "" {
+		scratch.At(t)
+	}
+	if scratch.err != nil {
+		return scratch.err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.version != version {
+		return ErrVersionConflict
+	}
+	j.ats, j.atTime, j.offset = scratch.ats, scratch.atTime, scratch.offset
+	j.anchorAt()
+	j.period = 0
+	j.scheduleNextRun()
+	j.version++
+	return nil
+}
@@ -0,0 +1,198 @@
+package gocron
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJob_SetUnitWhileRunning(t *testing.T) {
+	scheduler := NewScheduler()
+	ran := make(chan struct{}, 10)
+	job := scheduler.Every(1).Hour()
+	job.Do(func() { ran <- struct{}{} })
+	job.mu.Lock()
+	job.startedAt = time.Now().Add(-time.Minute)
+	job.mu.Unlock()
+	history := job.LastRun()
+
+	scheduler.Start()
+	// Stop returns once the loop has ended
+	defer scheduler.Stop()
+	if err := job.SetUnit(UnitSeconds); err != nil {
+		t.Fatal(err)
+	}
+	if got := job.String(); !strings.HasPrefix(got, "[gocron.TestJob_SetUnitWhileRunning.func1] every second, next ") {
+		t.Errorf("String() = %q after SetUnit", got)
+	}
+	if !job.LastRun().Equal(history) {
+		t.Error("SetUnit lost the job's last run")
+	}
+	select {
+	case <-ran:
+	case <-time.After(3 * time.Second):
+		t.Fatal("the job did not run on its new schedule")
+	}
+	if jobs := scheduler.Jobs(); len(jobs) != 1 || jobs[0] != job {
+		t.Error("the job was replaced")
+	}
+
+	if err := job.SetInterval(0); err == nil {
+		t.Error("SetInterval(0) should fail")
+	}
+	if err := job.SetInterval(2); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := job.TimeUntilNextRun(); d > 2*time.Second {
+		t.Errorf("next run in %v, want at most 2s", d)
+	}
+}
+
+func TestJob_SetAt(t *testing.T) {
+	scheduler := NewScheduler()
+	daily := scheduler.Every(1).Day().At("10:30")
+	daily.Do(task)
+	v := daily.Version()
+	if err := daily.SetAt("07:15;19:45"); err != nil {
+		t.Fatal(err)
+	}
+	next := daily.NextScheduledTime()
+	if c := (timeOfDay{next.Hour(), next.Minute(), next.Second()}); c != (timeOfDay{7, 15, 0}) && c != (timeOfDay{19, 45, 0}) {
+		t.Errorf("next run at %v, want one of the new At times", next)
+	}
+	if daily.Version() == v {
+		t.Error("SetAt did not bump the version")
+	}
+	if err := daily.SetAt("25:00"); err == nil {
+		t.Error("SetAt of a malformed time should fail")
+	}
+	if times := daily.AtTimes(); len(times) != 2 {
+		t.Errorf("AtTimes() = %v after a failed SetAt, want the old times", times)
+	}
+
+	hourly := scheduler.Every(1).Hour().At("05")
+	hourly.Do(task)
+	if err := hourly.SetAt("45"); err != nil {
+		t.Fatal(err)
+	}
+	if hourly.AtTime() != 45*time.Minute || hourly.NextScheduledTime().Minute() != 45 {
+		t.Errorf("hourly job at %v, next run %v, want 45 minutes past the hour", hourly.AtTime(), hourly.NextScheduledTime())
+	}
+	if err := hourly.SetAt(""); err != nil || hourly.AtTime() != 0 {
+		t.Errorf("SetAt(\"\") = %v, At time %v, want it dropped", err, hourly.AtTime())
+	}
+
+	if err := scheduler.Cron("0 * * * *").SetAt("10:00"); err == nil {
+		t.Error("SetAt on a cron job should fail")
+	}
+}
+
+func TestJob_RescheduleIfVersion(t *testing.T) {
+	job := NewScheduler().Every(1).Hour()
+	job.Do(task)
+	base := job.Version()
+
+	results := make(chan error, 2)
+	var wg sync.WaitGroup
+	for _, interval := range []uint64{2, 3} {
+		wg.Add(1)
+		go func(interval uint64) {
+			defer wg.Done()
+			results <- job.RescheduleIfVersion(base, interval, UnitMinutes)
+		}(interval)
+	}
+	wg.Wait()
+	close(results)
+
+	var ok, conflicts int
+	for err := range results {
+		switch err {
+		case nil:
+			ok++
+		case ErrVersionConflict:
+			conflicts++
+		default:
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	if ok != 1 || conflicts != 1 {
+		t.Errorf("got %d successes and %d conflicts, want exactly one of each", ok, conflicts)
+	}
+	if job.Version() != base+1 {
+		t.Errorf("Version() = %d, want %d", job.Version(), base+1)
+	}
+	if job.unit != UnitMinutes {
+		t.Errorf("unit = %s, want %s", job.unit, UnitMinutes)
+	}
+}
+
+func TestJob_RescheduleInvalid(t *testing.T) {
+	job := NewScheduler().Every(1).Hour()
+	job.Do(task)
+	if err := job.Reschedule(1, "fortnights"); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+	if err := job.Reschedule(0, UnitMinutes); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+	if job.Version() != 0 {
+		t.Errorf("failed reschedules must not bump the version, got %d", job.Version())
+	}
+}
+
+func TestJob_VersionExportLoad(t *testing.T) {
+	old := NewScheduler()
+	job := old.Every(1).Hour().Tag("report")
+	job.Do(task)
+	job.Label("team", "ops")
+	job.RescheduleIfVersion(job.Version(), 2, UnitHours)
+	saved := job.Version()
+
+	states := old.Export()
+	if states[0].Version != saved {
+		t.Fatalf("exported version %d, want %d", states[0].Version, saved)
+	}
+	restarted := NewScheduler()
+	again := restarted.Every(2).Hours().Tag("report")
+	again.Do(task)
+	restarted.Load(states)
+	if again.Version() != saved {
+		t.Errorf("Version() = %d after Load, want %d", again.Version(), saved)
+	}
+	if err := again.RescheduleIfVersion(saved, 3, UnitHours); err != nil {
+		t.Errorf("RescheduleIfVersion() with the saved version = %v", err)
+	}
+}
+
+// run with -race: the mutators record their errors under the job's lock
+func TestJob_MutatorsConcurrentWithErr(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetLogger(&recordingLogger{})
+	scheduler.TagsUnique(true)
+	scheduler.Every(1).Hour().Tag("taken").Do(task)
+	job := scheduler.Every(1).Hour()
+	job.Do(task)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				job.Label("bad key", "x")
+				job.Tag("taken")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				job.Err()
+			}
+		}()
+	}
+	wg.Wait()
+	if job.Err() == nil {
+		t.Error("Err() = nil after invalid labels and taken tags")
+	}
+}
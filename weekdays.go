@@ -0,0 +1,111 @@
+package gocron
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// weekdaySet has bit d set for each day d of a weekly job
+type weekdaySet uint8
+
+func (w weekdaySet) has(d time.Weekday) bool {
+	return w&(1<<uint(d)) != 0
+}
+
+// How many days are in the set
+func (w weekdaySet) len() int {
+	n := 0
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if w.has(d) {
+			n++
+		}
+	}
+	return n
+}
+
+// The days in order from Sunday
+func (w weekdaySet) days() []time.Weekday {
+	var days []time.Weekday
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if w.has(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// Weekdays - The days a weekly job runs on, in order from Sunday. Each
+// call of a day method adds a day, so s.Every(1).Monday().Wednesday().Friday()
+// runs three times a week. Several days require an interval of 1.
+func (j *Job) Weekdays() []time.Weekday {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.unit != UnitWeeks {
+		return nil
+	}
+	if j.weekdays == 0 {
+		return []time.Weekday{j.startDay}
+	}
+	return j.weekdays.days()
+}
+
+// Add day d to the job's days
+func (j *Job) addWeekday(name string, d time.Weekday) {
+	j.weekdays |= 1 << uint(d)
+	if j.weekdays.len() == 1 {
+		j.startDay = d
+		return
+	}
+	if j.interval != 1 {
+		j.setErr(errors.New(name + "() on a job with several weekdays requires an interval of 1"))
+		return
+	}
+	// an At call before this one anchored the job to the first day alone
+	j.lastRun = time.Unix(0, 0)
+}
+
+// Whether the job runs on more than one day of the week
+func (j *Job) severalWeekdays() bool {
+	return j.unit == UnitWeeks && j.weekdays.len() > 1
+}
+
+// Compute nextRun of a job with several weekdays: the first At time, or
+// midnight, on one of its days after the last run or, before the first
+// one, after now
+func (j *Job) scheduleWeekdays() {
+	loc := j.location()
+	from := j.now().In(loc)
+	if j.lastRun != time.Unix(0, 0) {
+		from = j.lastRun.In(loc)
+	}
+	ats := j.ats
+	if len(ats) == 0 {
+		ats = []timeOfDay{{}}
+	}
+	for d := 0; d <= 7; d++ {
+		day := from.AddDate(0, 0, d)
+		if !j.weekdays.has(day.Weekday()) {
+			continue
+		}
+		for _, c := range ats {
+			if t := c.on(day); t.After(from) {
+				j.nextRun = t
+				return
+			}
+		}
+	}
+}
+
+// "monday, wednesday and friday"
+func (w weekdaySet) String() string {
+	var names []string
+	for _, d := range w.days() {
+		names = append(names, strings.ToLower(d.String()))
+	}
+	last := len(names) - 1
+	if last < 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:last], ", ") + " and " + names[last]
+}
@@ -0,0 +1,135 @@
+package gocron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJob_SeveralWeekdays(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(1).Monday().Wednesday().Friday().At("07:00").Loc(time.UTC)
+	if err := job.Do(task); err != nil {
+		t.Fatal(err)
+	}
+	if got := job.String(); !strings.HasPrefix(got, "[gocron.task] every monday, wednesday and friday at 07:00, next ") {
+		t.Errorf("String() = %q", got)
+	}
+	if days := job.Weekdays(); len(days) != 3 || days[0] != time.Monday || days[2] != time.Friday {
+		t.Errorf("Weekdays() = %v, want Monday, Wednesday and Friday", days)
+	}
+	first := job.NextScheduledTime()
+	if now := time.Now(); !first.After(now) || first.After(now.AddDate(0, 0, 3)) {
+		t.Errorf("first run %v, want the nearest listed day", first)
+	}
+
+	// a Monday at 07:00, then the rest of the week
+	job.mu.Lock()
+	job.lastRun = time.Date(2024, time.January, 1, 7, 0, 0, 0, time.UTC)
+	var runs []time.Time
+	for i := 0; i < 4; i++ {
+		job.scheduleNextRun()
+		runs = append(runs, job.nextRun)
+		job.lastRun = job.nextRun
+	}
+	job.mu.Unlock()
+	want := []int{3, 5, 8, 10}
+	for i, run := range runs {
+		if run.Day() != want[i] || run.Hour() != 7 || run.Minute() != 0 {
+			t.Errorf("run %d at %v, want January %d 07:00", i, run, want[i])
+		}
+	}
+}
+
+func TestJob_SeveralWeekdaysTodayPassed(t *testing.T) {
+	now := time.Now().UTC()
+	at := now.Add(-time.Minute)
+	if at.Day() != now.Day() {
+		t.Skip("too close to midnight")
+	}
+	today, later := now.Weekday(), (now.Weekday()+2)%7
+	job := NewScheduler().Every(1).Weekday(today).Weekday(later).At(at.Format("15:04")).Loc(time.UTC)
+	if err := job.Do(task); err != nil {
+		t.Fatal(err)
+	}
+	next := job.NextScheduledTime()
+	if next.Weekday() != later || next.Sub(now) > 2*24*time.Hour {
+		t.Errorf("next run %v, want the next listed day once today's time passed", next)
+	}
+}
+
+func TestJob_SameWeekdayTwice(t *testing.T) {
+	scheduler := NewScheduler()
+	twice := scheduler.Every(1).Monday().Monday().At("07:00").Loc(time.UTC)
+	once := scheduler.Every(1).Monday().At("07:00").Loc(time.UTC)
+	twice.Do(task)
+	once.Do(task)
+	if days := twice.Weekdays(); len(days) != 1 {
+		t.Errorf("Weekdays() = %v, want Monday once", days)
+	}
+	if twice.String() != once.String() || !twice.NextScheduledTime().Equal(once.NextScheduledTime()) {
+		t.Errorf("Monday twice built %q next at %v, want %q", twice, twice.NextScheduledTime(), once)
+	}
+
+	// days added after At still count
+	job := scheduler.Every(1).Monday().At("07:00").Friday().Loc(time.UTC)
+	job.Do(task)
+	if d := job.NextScheduledTime().Weekday(); d != time.Monday && d != time.Friday {
+		t.Errorf("next run on %v, want Monday or Friday", d)
+	}
+
+	if err := scheduler.Every(2).Monday().Friday().Do(task); err == nil {
+		t.Error("several weekdays with an interval of 2 should fail")
+	}
+}
+
+func TestScheduler_WeekdaysInterval(t *testing.T) {
+	scheduler := NewScheduler()
+	job := scheduler.Every(2).Monday().At("09:00").Loc(time.UTC)
+	if err := job.Do(task); err != nil {
+		t.Fatal(err)
+	}
+	next := job.NextScheduledTime()
+	if now := time.Now(); !next.After(now) || next.After(now.AddDate(0, 0, 7)) {
+		t.Errorf("first run %v, want the next Monday", next)
+	}
+	for i := 0; i < 4; i++ {
+		if next.Weekday() != time.Monday || next.Hour() != 9 || next.Minute() != 0 {
+			t.Fatalf("run %v, want a Monday at 09:00", next)
+		}
+		job.mu.Lock()
+		job.lastRun = job.nextRun
+		job.scheduleNextRun()
+		following := job.nextRun
+		job.mu.Unlock()
+		if gap := following.Sub(next); gap != 14*24*time.Hour {
+			t.Errorf("gap after %v is %v, want 14 days", next, gap)
+		}
+		next = following
+	}
+
+	same := scheduler.Every(2).Weekday(time.Monday).At("09:00").Loc(time.UTC)
+	same.Do(task)
+	if schedule(same) != schedule(job) || !same.NextScheduledTime().Equal(job.NextScheduledTime().AddDate(0, 0, -56)) {
+		t.Errorf("Weekday(time.Monday) built %q next at %v, want %q", schedule(same), same.NextScheduledTime(), schedule(job))
+	}
+}
+
+func TestScheduler_WeekdaysIntervalStartAt(t *testing.T) {
+	// a Monday long past anchors the fortnights
+	anchor := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	scheduler := NewScheduler()
+	for i := 0; i < 2; i++ {
+		job := scheduler.Every(2).Monday().StartAt(anchor).Loc(time.UTC)
+		if err := job.Do(task); err != nil {
+			t.Fatal(err)
+		}
+		next := job.NextScheduledTime()
+		if now := time.Now(); !next.After(now) || next.After(now.AddDate(0, 0, 14)) {
+			t.Errorf("first run %v, want the next slot after now", next)
+		}
+		if days := next.Sub(anchor) / (24 * time.Hour); days%14 != 0 || next.Hour() != 9 {
+			t.Errorf("first run %v is not a fortnight multiple from %v", next, anchor)
+		}
+	}
+}